@@ -2,38 +2,54 @@ package api
 
 import "time"
 
+type Profile struct {
+	// ID identifies the profile among the profiles of the browser, and is passed to the other methods of the browser
+	ID string `json:"id" yaml:"id"`
+	// Name is the name of the profile displayed by the browser
+	Name string `json:"name" yaml:"name"`
+	// Path is the directory containing the files of the profile
+	Path     string    `json:"path" yaml:"path"`
+	Default  bool      `json:"default,omitempty" yaml:"default,omitempty"`
+	Account  string    `json:"account,omitempty" yaml:"account,omitempty"`
+	LastUsed time.Time `json:"last_used,omitempty" yaml:"last_used,omitempty"`
+	// Install is the name of the install of the browser the profile comes from (flatpak, snap, ...), empty for the native install
+	Install string `json:"install,omitempty" yaml:"install,omitempty"`
+	// DefaultFor are the IDs of the installs of the browser using the profile as their default profile (Firefox)
+	DefaultFor []string `json:"default_for,omitempty" yaml:"default_for,omitempty"`
+}
+
 type BookMark struct {
-	Name            string    `yaml:"name"`
-	URL             string    `yaml:"url"`
-	Folder          []string  `yaml:"folder"`
-	DateAdded       time.Time `yaml:"date_added,omitempty"`
-	DateModified    time.Time `yaml:"date_modified,omitempty"`
-	DateLastVisited time.Time `yaml:"date_last_visited,omitempty"`
+	Name            string    `json:"name" yaml:"name"`
+	URL             string    `json:"url" yaml:"url"`
+	Folder          []string  `json:"folder" yaml:"folder"`
+	DateAdded       time.Time `json:"date_added,omitempty" yaml:"date_added,omitempty"`
+	DateModified    time.Time `json:"date_modified,omitempty" yaml:"date_modified,omitempty"`
+	DateLastVisited time.Time `json:"date_last_visited,omitempty" yaml:"date_last_visited,omitempty"`
 }
 
 type SearchEngineQuery struct {
-	Query        string    `yaml:"query"`
-	Date         time.Time `yaml:"date"`
-	SearchEngine string    `yaml:"search_engine"`
+	Query        string    `json:"query" yaml:"query"`
+	Date         time.Time `json:"date" yaml:"date"`
+	SearchEngine string    `json:"search_engine" yaml:"search_engine"`
 }
 
 type SearchEngineOptions struct {
-	StartTime time.Time
-	EndTime   time.Time
-	Limit     int
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Limit     int       `json:"limit"`
 }
 
 type VisitedPageFromSearchEngineQuery struct {
-	URL          string    `yaml:"url"`
-	Title        string    `yaml:"title"`
-	Date         time.Time `yaml:"date"`
-	SearchEngine string    `yaml:"search_engine"`
+	URL          string    `json:"url" yaml:"url"`
+	Title        string    `json:"title" yaml:"title"`
+	Date         time.Time `json:"date" yaml:"date"`
+	SearchEngine string    `json:"search_engine" yaml:"search_engine"`
 }
 
 type ListVisitedPagesFromSearchEngineQueryOptions struct {
-	Query     string
-	StartTime time.Time
-	EndTime   time.Time
+	Query     string    `json:"query"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
 }
 
 type SourceRepoPageType string
@@ -53,27 +69,153 @@ const (
 )
 
 type VisitedPageFromSourceRepos struct {
-	Times        int                `yaml:"times"`
-	Provider     string             `yaml:"provider"` // github, ...
-	URL          string             `yaml:"url"`
-	Organization string             `yaml:"organization"`
-	Repository   string             `yaml:"repository"`
-	Type         SourceRepoPageType `yaml:"type"`             // provider home, issue, pull request, ...
-	Number       *string            `yaml:"number,omitempty"` // Depending on type: number of issue/PR/etc, not defined for home
+	Times        int                `json:"times" yaml:"times"`
+	Provider     string             `json:"provider" yaml:"provider"` // github, ...
+	URL          string             `json:"url" yaml:"url"`
+	Organization string             `json:"organization" yaml:"organization"`
+	Repository   string             `json:"repository" yaml:"repository"`
+	Type         SourceRepoPageType `json:"type" yaml:"type"`                         // provider home, issue, pull request, ...
+	Number       *string            `json:"number,omitempty" yaml:"number,omitempty"` // Depending on type: number of issue/PR/etc, not defined for home
 }
 
 type ListVisitedPagesFromSourceReposOptions struct {
-	Type      SourceRepoPageType
-	StartTime time.Time
-	EndTime   time.Time
+	Type      SourceRepoPageType `json:"type"`
+	StartTime time.Time          `json:"start_time"`
+	EndTime   time.Time          `json:"end_time"`
 }
 
 type Browser interface {
 	Name() string
 	IsAvailable() (bool, error)
-	Profiles() ([]string, error)
+	Profiles() ([]Profile, error)
 	Bookmarks(profile string) ([]BookMark, error)
 	SearchEngineQueries(profile string, options SearchEngineOptions) ([]SearchEngineQuery, error)
 	ListVisitedPagesFromSearchEngineQuery(profile string, options ListVisitedPagesFromSearchEngineQueryOptions) ([]VisitedPageFromSearchEngineQuery, error)
 	ListVisitedPagesFromSourceRepos(profile string, options ListVisitedPagesFromSourceReposOptions) ([]VisitedPageFromSourceRepos, error)
 }
+
+type Note struct {
+	Title   string   `yaml:"title,omitempty"`
+	Content string   `yaml:"content"`
+	URL     string   `yaml:"url,omitempty"`
+	Folder  []string `yaml:"folder"`
+	// Attachments is the number of files attached to the note
+	Attachments  int       `yaml:"attachments,omitempty"`
+	DateAdded    time.Time `yaml:"date_added,omitempty"`
+	DateModified time.Time `yaml:"date_modified,omitempty"`
+}
+
+type ListNotesOptions struct {
+	// Folder selects the notes of a folder and its subfolders, designated by its path separated with slashes
+	Folder string
+	// Text selects the notes containing a text in their title, content or URL, case-insensitive
+	Text string
+	// CreatedStartTime, CreatedEndTime, ModifiedStartTime and ModifiedEndTime are ignored when zero
+	CreatedStartTime  time.Time
+	CreatedEndTime    time.Time
+	ModifiedStartTime time.Time
+	ModifiedEndTime   time.Time
+}
+
+// NotesReader is implemented by the browsers letting users take notes
+type NotesReader interface {
+	ListNotes(profile string, options ListNotesOptions) ([]Note, error)
+}
+
+type Collection struct {
+	Title        string           `yaml:"title"`
+	DateAdded    time.Time        `yaml:"date_added,omitempty"`
+	DateModified time.Time        `yaml:"date_modified,omitempty"`
+	Items        []CollectionItem `yaml:"items"`
+}
+
+type CollectionItem struct {
+	Title     string    `yaml:"title,omitempty"`
+	URL       string    `yaml:"url,omitempty"`
+	Note      string    `yaml:"note,omitempty"`
+	DateAdded time.Time `yaml:"date_added,omitempty"`
+}
+
+type ListCollectionsOptions struct {
+	// Collection selects a collection by its title, case-insensitive
+	Collection string
+}
+
+// CollectionsReader is implemented by the browsers letting users group saved pages in collections
+type CollectionsReader interface {
+	ListCollections(profile string, options ListCollectionsOptions) ([]Collection, error)
+}
+
+type Tab struct {
+	Title string `yaml:"title"`
+	URL   string `yaml:"url"`
+	// Type is the type of the DevTools target (page, background_page, service_worker, ...), empty for the tabs read from the files of the browser
+	Type string `yaml:"type,omitempty"`
+	// Window is the number of the window containing the tab, starting at 1, when known
+	Window       int       `yaml:"window,omitempty"`
+	Pinned       bool      `yaml:"pinned,omitempty"`
+	Container    string    `yaml:"container,omitempty"`
+	Group        string    `yaml:"group,omitempty"`
+	GroupColor   string    `yaml:"group_color,omitempty"`
+	LastAccessed time.Time `yaml:"last_accessed,omitempty"`
+	// Back and Forward are the pages of the back/forward history of the tab, in navigation order
+	Back    []TabEntry `yaml:"back,omitempty"`
+	Forward []TabEntry `yaml:"forward,omitempty"`
+}
+
+type TabEntry struct {
+	Title string `yaml:"title"`
+	URL   string `yaml:"url"`
+}
+
+type ListOpenTabsOptions struct {
+	// DevToolsEndpoint is the DevTools endpoint configured for the browser ("http://localhost:9222"), empty if not configured.
+	// It is used by the browsers of the Chromium family only.
+	DevToolsEndpoint string
+}
+
+// OpenTabsReader is implemented by the browsers able to list the tabs currently open
+type OpenTabsReader interface {
+	ListOpenTabs(profile string, options ListOpenTabsOptions) ([]Tab, error)
+}
+
+type ClosedTab struct {
+	Title    string    `yaml:"title"`
+	URL      string    `yaml:"url"`
+	ClosedAt time.Time `yaml:"closed_at,omitempty"`
+}
+
+type ClosedWindow struct {
+	Title    string    `yaml:"title"`
+	ClosedAt time.Time `yaml:"closed_at,omitempty"`
+	Tabs     []Tab     `yaml:"tabs"`
+}
+
+type RecentlyClosed struct {
+	Tabs    []ClosedTab    `yaml:"tabs"`
+	Windows []ClosedWindow `yaml:"windows"`
+}
+
+// RecentlyClosedReader is implemented by the browsers able to list the recently closed tabs and windows
+type RecentlyClosedReader interface {
+	ListRecentlyClosed(profile string) (RecentlyClosed, error)
+}
+
+type SyncedTab struct {
+	Title    string    `yaml:"title"`
+	URL      string    `yaml:"url"`
+	LastUsed time.Time `yaml:"last_used,omitempty"`
+	Pinned   bool      `yaml:"pinned,omitempty"`
+}
+
+// SyncedDevice is another device of the user, with the tabs open on it when it last synced
+type SyncedDevice struct {
+	Name         string      `yaml:"name"`
+	LastModified time.Time   `yaml:"last_modified,omitempty"`
+	Tabs         []SyncedTab `yaml:"tabs"`
+}
+
+// SyncedTabsReader is implemented by the browsers able to list the tabs synced from the other devices of the user
+type SyncedTabsReader interface {
+	ListSyncedTabs(profile string) ([]SyncedDevice, error)
+}
@@ -0,0 +1,58 @@
+package arc
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/arc/files"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/chrome"
+)
+
+// Vendor describes where Arc stores its Chromium user data.
+// The sidebar is stored in the parent directory of the user data directory.
+var Vendor = chrome.Vendor{
+	Name:    "arc",
+	Darwin:  []string{"Arc", "User Data"},
+	Windows: []string{"Packages", "TheBrowserCompany.Arc_ttt1ap7aakyb4", "LocalCache", "Local", "Arc", "User Data"},
+}
+
+// Arc is a provider for the Arc browser, which reads the profiles, the bookmarks and the history
+// with the Chromium provider, and adds the pinned tabs of the spaces of the sidebar to the bookmarks.
+type Arc struct {
+	*chrome.Chromium
+}
+
+func New() *Arc {
+	return &Arc{Chromium: chrome.New(Vendor)}
+}
+
+// Bookmarks returns the Chromium bookmarks of the profile, followed by the pinned tabs of the spaces attached to it
+func (o *Arc) Bookmarks(profileName string) ([]api.BookMark, error) {
+	profile, err := o.Profile(profileName)
+	if err != nil {
+		return nil, err
+	}
+	result, err := o.Chromium.Bookmarks(profileName)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	sidebar, err := files.ReadSidebar(filepath.Join(filepath.Dir(filepath.Dir(profile.Path)), "StorableSidebar.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	pinned, err := sidebar.ListBookmarks(filepath.Base(profile.Path))
+	if err != nil {
+		return nil, err
+	}
+	return append(result, pinned...), nil
+}
+
+func init() {
+	browsers.Register(New())
+}
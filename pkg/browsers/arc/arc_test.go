@@ -0,0 +1,94 @@
+package arc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/test"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBookmarks(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "darwin"
+	defer func() { system.Os = "linux" }()
+	arcPath := filepath.Join(os.Getenv("HOME"), "Library", "Application Support", "Arc")
+	system.WriteFile(filepath.Join(arcPath, "User Data", "Local State"), []byte(`{
+  "profile": {
+    "profiles_order": ["Default", "Profile 1"]
+  }
+}`), 0644)
+	system.WriteFile(filepath.Join(arcPath, "User Data", "Profile 1", "Bookmarks"), []byte(`{
+  "roots": {
+    "bookmark_bar": {
+      "children": [{ "name": "Docs", "type": "url", "url": "https://docs.example.com" }],
+      "name": "Bookmarks bar",
+      "type": "folder"
+    }
+  }
+}`), 0644)
+	system.WriteFile(filepath.Join(arcPath, "StorableSidebar.json"), []byte(`{
+  "sidebar": {
+    "containers": [
+      { "global": {} },
+      {
+        "spaces": [
+          "space-work",
+          {
+            "id": "space-work",
+            "title": "Work",
+            "containerIDs": ["pinned", "work-pinned"],
+            "profile": { "custom": { "_0": { "directoryBasename": "Profile 1" } } }
+          }
+        ],
+        "items": [
+          "work-pinned",
+          { "id": "work-pinned", "childrenIds": ["tracker"], "data": { "itemContainer": {} } },
+          "tracker",
+          { "id": "tracker", "childrenIds": [], "data": { "tab": { "savedTitle": "Issues", "savedURL": "https://tracker.example.com" } } }
+        ]
+      }
+    ]
+  }
+}`), 0644)
+
+	arc := New()
+	if arc.Name() != "arc" {
+		t.Errorf("expected arc, got %s", arc.Name())
+	}
+	profiles, err := arc.Profiles()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := []string{"Default", "Profile 1"}; !cmp.Equal(expected, test.ProfileIDs(profiles)) {
+		t.Errorf("expected %v, got %v", expected, test.ProfileIDs(profiles))
+	}
+
+	for profile, expected := range map[string][]string{
+		"Default":   {},
+		"Profile 1": {"https://docs.example.com", "https://tracker.example.com"},
+	} {
+		bookmarks, err := arc.Bookmarks(profile)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", profile, err)
+		}
+		urls := []string{}
+		for _, bookmark := range bookmarks {
+			urls = append(urls, bookmark.URL)
+		}
+		if !cmp.Equal(expected, urls) {
+			t.Errorf("%s: expected %v, got %v", profile, expected, urls)
+		}
+	}
+}
+
+func TestNotAvailableOnLinux(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	if available, _ := New().IsAvailable(); available {
+		t.Error("expected arc not to be available on linux")
+	}
+}
@@ -0,0 +1,186 @@
+package files
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+// CoreDataOrigin is the origin of the times of the sidebar, in seconds since the Unix epoch
+var CoreDataOrigin = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// name of the directory of the profile used by the spaces not attached to a custom profile
+const defaultProfile = "Default"
+
+// StorableSidebar contains the sidebar of Arc, stored in the `StorableSidebar.json` file.
+type StorableSidebar struct {
+	Sidebar struct {
+		Containers []SidebarContainer `json:"containers"`
+	} `json:"sidebar"`
+}
+
+// SidebarContainer contains the spaces and the items of the sidebar.
+// Spaces and items are lists alternating IDs and objects.
+type SidebarContainer struct {
+	Spaces []json.RawMessage `json:"spaces"`
+	Items  []json.RawMessage `json:"items"`
+}
+
+// Space is a space of the sidebar, attached to a profile
+type Space struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	// ContainerIDs alternates the types of the containers ("pinned", "unpinned") and their IDs
+	ContainerIDs []json.RawMessage `json:"containerIDs"`
+	Profile      SpaceProfile      `json:"profile"`
+}
+
+// SpaceProfile is the profile of a space, either the default one or a custom one
+type SpaceProfile struct {
+	Default bool `json:"default"`
+	Custom  *struct {
+		Value struct {
+			DirectoryBasename string `json:"directoryBasename"`
+		} `json:"_0"`
+	} `json:"custom"`
+}
+
+// directory returns the name of the directory of the profile
+func (o SpaceProfile) directory() string {
+	if o.Custom != nil && o.Custom.Value.DirectoryBasename != "" {
+		return o.Custom.Value.DirectoryBasename
+	}
+	return defaultProfile
+}
+
+// Item is an item of the sidebar: a tab, a folder or a container of items
+type Item struct {
+	ID          string   `json:"id"`
+	ParentID    string   `json:"parentID"`
+	ChildrenIDs []string `json:"childrenIds"`
+	Title       *string  `json:"title"`
+	CreatedAt   float64  `json:"createdAt"`
+	Data        struct {
+		Tab *struct {
+			SavedTitle string `json:"savedTitle"`
+			SavedURL   string `json:"savedURL"`
+		} `json:"tab"`
+		List *struct{} `json:"list"`
+	} `json:"data"`
+}
+
+// ReadSidebar reads the `StorableSidebar.json` file
+func ReadSidebar(path string) (*StorableSidebar, error) {
+	data, err := system.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var sidebar StorableSidebar
+	if err = json.Unmarshal(data, &sidebar); err != nil {
+		return nil, err
+	}
+	return &sidebar, nil
+}
+
+// ListBookmarks returns the pinned tabs of the spaces attached to a profile, designated by the name of its directory.
+// The folder of a pinned tab starts with the title of its space, followed by the titles of the folders containing it.
+func (o *StorableSidebar) ListBookmarks(profileDirectory string) ([]api.BookMark, error) {
+	result := []api.BookMark{}
+	for _, container := range o.Sidebar.Containers {
+		var spaces []Space
+		if err := decodeObjects(container.Spaces, &spaces); err != nil {
+			return nil, err
+		}
+		var items []Item
+		if err := decodeObjects(container.Items, &items); err != nil {
+			return nil, err
+		}
+		itemsByID := map[string]Item{}
+		for _, item := range items {
+			itemsByID[item.ID] = item
+		}
+		for _, space := range spaces {
+			if space.Profile.directory() != profileDirectory {
+				continue
+			}
+			pinned, err := space.pinnedContainerID()
+			if err != nil {
+				return nil, err
+			}
+			if pinned == "" {
+				continue
+			}
+			result = append(result, flatItemsRec(itemsByID, itemsByID[pinned].ChildrenIDs, []string{space.Title})...)
+		}
+	}
+	return result, nil
+}
+
+// pinnedContainerID returns the ID of the container of the pinned tabs of the space
+func (o Space) pinnedContainerID() (string, error) {
+	var values []any
+	for _, raw := range o.ContainerIDs {
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return "", err
+		}
+		values = append(values, value)
+	}
+	for i := 0; i+1 < len(values); i += 2 {
+		if values[i] == "pinned" {
+			id, _ := values[i+1].(string)
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+func flatItemsRec(itemsByID map[string]Item, ids []string, folder []string) []api.BookMark {
+	result := []api.BookMark{}
+	for _, id := range ids {
+		item, ok := itemsByID[id]
+		if !ok {
+			continue
+		}
+		switch {
+		case item.Data.Tab != nil:
+			name := item.Data.Tab.SavedTitle
+			if item.Title != nil && *item.Title != "" {
+				name = *item.Title
+			}
+			bookmark := api.BookMark{
+				Name:   name,
+				URL:    item.Data.Tab.SavedURL,
+				Folder: folder,
+			}
+			if item.CreatedAt != 0 {
+				bookmark.DateAdded = CoreDataOrigin.Add(time.Duration(item.CreatedAt * float64(time.Second))).Round(time.Millisecond)
+			}
+			result = append(result, bookmark)
+		case item.Data.List != nil:
+			title := ""
+			if item.Title != nil {
+				title = *item.Title
+			}
+			result = append(result, flatItemsRec(itemsByID, item.ChildrenIDs, append(folder[:len(folder):len(folder)], title))...)
+		}
+	}
+	return result
+}
+
+// decodeObjects decodes the objects of a list alternating IDs and objects
+func decodeObjects[T any](values []json.RawMessage, result *[]T) error {
+	for _, raw := range values {
+		if len(raw) == 0 || raw[0] != '{' {
+			continue
+		}
+		var value T
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return err
+		}
+		*result = append(*result, value)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package files
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+const sidebarFixture = `{
+  "sidebar": {
+    "containers": [
+      { "global": {} },
+      {
+        "spaces": [
+          "space-personal",
+          {
+            "id": "space-personal",
+            "title": "Personal",
+            "containerIDs": ["unpinned", "personal-unpinned", "pinned", "personal-pinned"],
+            "profile": { "default": true }
+          },
+          "space-work",
+          {
+            "id": "space-work",
+            "title": "Work",
+            "containerIDs": ["pinned", "work-pinned", "unpinned", "work-unpinned"],
+            "profile": { "custom": { "_0": { "directoryBasename": "Profile 1" } } }
+          }
+        ],
+        "items": [
+          "personal-pinned",
+          {
+            "id": "personal-pinned",
+            "childrenIds": ["news", "reading"],
+            "title": null,
+            "data": { "itemContainer": { "containerType": { "spaceItems": { "_0": "space-personal" } } } }
+          },
+          "news",
+          {
+            "id": "news",
+            "parentID": "personal-pinned",
+            "childrenIds": [],
+            "title": null,
+            "createdAt": 735000000.5,
+            "data": { "tab": { "savedTitle": "News", "savedURL": "https://news.example.com" } }
+          },
+          "reading",
+          {
+            "id": "reading",
+            "parentID": "personal-pinned",
+            "childrenIds": ["blog"],
+            "title": "Reading",
+            "data": { "list": {} }
+          },
+          "blog",
+          {
+            "id": "blog",
+            "parentID": "reading",
+            "childrenIds": [],
+            "title": "My blog",
+            "createdAt": 735000100,
+            "data": { "tab": { "savedTitle": "Blog - Home", "savedURL": "https://blog.example.com" } }
+          },
+          "personal-unpinned",
+          {
+            "id": "personal-unpinned",
+            "childrenIds": ["today"],
+            "title": null,
+            "data": { "itemContainer": {} }
+          },
+          "today",
+          {
+            "id": "today",
+            "parentID": "personal-unpinned",
+            "childrenIds": [],
+            "title": null,
+            "data": { "tab": { "savedTitle": "Today", "savedURL": "https://today.example.com" } }
+          },
+          "work-pinned",
+          {
+            "id": "work-pinned",
+            "childrenIds": ["tracker"],
+            "title": null,
+            "data": { "itemContainer": {} }
+          },
+          "tracker",
+          {
+            "id": "tracker",
+            "parentID": "work-pinned",
+            "childrenIds": [],
+            "title": null,
+            "data": { "tab": { "savedTitle": "Issues", "savedURL": "https://tracker.example.com" } }
+          }
+        ]
+      }
+    ]
+  }
+}`
+
+func TestListBookmarks(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	path := filepath.Join("/arc", "StorableSidebar.json")
+	system.WriteFile(path, []byte(sidebarFixture), 0644)
+
+	sidebar, err := ReadSidebar(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, tt := range []struct {
+		profile  string
+		expected []api.BookMark
+	}{
+		{
+			profile: "Default",
+			expected: []api.BookMark{
+				{
+					Name:      "News",
+					URL:       "https://news.example.com",
+					Folder:    []string{"Personal"},
+					DateAdded: time.Date(2024, 4, 16, 22, 40, 0, 500000000, time.UTC),
+				},
+				{
+					Name:      "My blog",
+					URL:       "https://blog.example.com",
+					Folder:    []string{"Personal", "Reading"},
+					DateAdded: time.Date(2024, 4, 16, 22, 41, 40, 0, time.UTC),
+				},
+			},
+		},
+		{
+			profile: "Profile 1",
+			expected: []api.BookMark{
+				{
+					Name:   "Issues",
+					URL:    "https://tracker.example.com",
+					Folder: []string{"Work"},
+				},
+			},
+		},
+		{
+			profile:  "Profile 2",
+			expected: []api.BookMark{},
+		},
+	} {
+		t.Run(tt.profile, func(t *testing.T) {
+			bookmarks, err := sidebar.ListBookmarks(tt.profile)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, bookmarks); diff != "" {
+				t.Errorf("bookmarks mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -56,3 +56,14 @@ func GetBrowserByName(name string) (api.Browser, error) {
 	}
 	return provider, nil
 }
+
+// GetBrowsersWith returns the available browsers implementing an optional capability, such as api.NotesReader
+func GetBrowsersWith[T any]() []api.Browser {
+	result := []api.Browser{}
+	for _, browser := range GetBrowsers() {
+		if _, ok := browser.(T); ok {
+			result = append(result, browser)
+		}
+	}
+	return result
+}
@@ -21,7 +21,7 @@ func TestGetBrowsers(t *testing.T) {
 					Name:           "browser1",
 					Available:      true,
 					AvailableError: nil,
-					Profiles:       []string{"profile1"},
+					Profiles:       test.Profiles("profile1"),
 					ProfilesError:  nil,
 					Bookmarks:      []api.BookMark{},
 					BookmarksError: nil,
@@ -30,7 +30,7 @@ func TestGetBrowsers(t *testing.T) {
 					Name:           "browser2",
 					Available:      false,
 					AvailableError: nil,
-					Profiles:       []string{"profile2"},
+					Profiles:       test.Profiles("profile2"),
 					ProfilesError:  nil,
 					Bookmarks:      []api.BookMark{},
 					BookmarksError: nil,
@@ -45,7 +45,7 @@ func TestGetBrowsers(t *testing.T) {
 					Name:           "browser1",
 					Available:      false,
 					AvailableError: errors.New("an errror"),
-					Profiles:       []string{"profile1"},
+					Profiles:       test.Profiles("profile1"),
 					ProfilesError:  nil,
 					Bookmarks:      []api.BookMark{},
 					BookmarksError: nil,
@@ -54,7 +54,7 @@ func TestGetBrowsers(t *testing.T) {
 					Name:           "browser2",
 					Available:      true,
 					AvailableError: nil,
-					Profiles:       []string{"profile2"},
+					Profiles:       test.Profiles("profile2"),
 					ProfilesError:  nil,
 					Bookmarks:      []api.BookMark{},
 					BookmarksError: nil,
@@ -97,7 +97,7 @@ func TestGetBrowserByName(t *testing.T) {
 					Name:           "browser1",
 					Available:      true,
 					AvailableError: nil,
-					Profiles:       []string{"profile1"},
+					Profiles:       test.Profiles("profile1"),
 					ProfilesError:  nil,
 					Bookmarks:      []api.BookMark{},
 					BookmarksError: nil,
@@ -114,7 +114,7 @@ func TestGetBrowserByName(t *testing.T) {
 					Name:           "browser1",
 					Available:      true,
 					AvailableError: nil,
-					Profiles:       []string{"profile1"},
+					Profiles:       test.Profiles("profile1"),
 					ProfilesError:  nil,
 					Bookmarks:      []api.BookMark{},
 					BookmarksError: nil,
@@ -131,7 +131,7 @@ func TestGetBrowserByName(t *testing.T) {
 					Name:           "browser1",
 					Available:      false,
 					AvailableError: nil,
-					Profiles:       []string{"profile1"},
+					Profiles:       test.Profiles("profile1"),
 					ProfilesError:  nil,
 					Bookmarks:      []api.BookMark{},
 					BookmarksError: nil,
@@ -148,7 +148,7 @@ func TestGetBrowserByName(t *testing.T) {
 					Name:           "browser1",
 					Available:      true,
 					AvailableError: errors.New("an error"),
-					Profiles:       []string{"profile1"},
+					Profiles:       test.Profiles("profile1"),
 					ProfilesError:  nil,
 					Bookmarks:      []api.BookMark{},
 					BookmarksError: nil,
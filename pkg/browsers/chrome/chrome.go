@@ -1,86 +1,310 @@
 package chrome
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"github.com/charmbracelet/log"
 
 	"github.com/feloy/browsers-mcp-server/pkg/api"
 	"github.com/feloy/browsers-mcp-server/pkg/browsers"
 	"github.com/feloy/browsers-mcp-server/pkg/browsers/chrome/files"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
 )
 
-var instance api.Browser = &Chrome{}
+// Chromium is a provider for the browsers of the Chromium family,
+// which all share the `Local State`, `Bookmarks` and `History` formats.
+type Chromium struct {
+	vendor Vendor
+	// wsl indicates that the provider reads the Windows install of the browser from a WSL distribution
+	wsl bool
+}
 
-type Chrome struct{}
+func New(vendor Vendor) *Chromium {
+	return &Chromium{vendor: vendor}
+}
+
+// NewWSL returns a provider for the Windows install of a browser, read from a WSL distribution
+func NewWSL(vendor Vendor) *Chromium {
+	return &Chromium{vendor: vendor, wsl: true}
+}
 
-func (o *Chrome) Name() string {
-	return "chrome"
+func (o *Chromium) Name() string {
+	if o.wsl {
+		return o.vendor.Name + " (windows)"
+	}
+	return o.vendor.Name
 }
 
-func (o *Chrome) IsAvailable() (bool, error) {
-	_, err := files.ReadLocalState()
+func (o *Chromium) IsAvailable() (bool, error) {
+	_, err := o.Profiles()
 	return err == nil, err
 }
 
-func (o *Chrome) Profiles() ([]string, error) {
-	localState, err := files.ReadLocalState()
+func (o *Chromium) Bookmarks(profileName string) ([]api.BookMark, error) {
+	profilePath, err := o.profilePath(profileName)
 	if err != nil {
 		return nil, err
 	}
-	return localState.Profile.ProfilesOrder, nil
+	return files.ListBookmarks(profilePath)
 }
 
-func (o *Chrome) Bookmarks(profileName string) ([]api.BookMark, error) {
-	profiles, err := o.Profiles()
+func (o *Chromium) SearchEngineQueries(profileName string, options api.SearchEngineOptions) ([]api.SearchEngineQuery, error) {
+	profilePath, err := o.profilePath(profileName)
 	if err != nil {
 		return nil, err
 	}
-	for _, profile := range profiles {
-		if profile == profileName {
-			return files.ListBookmarks(profile)
+	return files.SearchEngineQueries(profilePath, options)
+}
+
+func (o *Chromium) ListVisitedPagesFromSearchEngineQuery(profileName string, options api.ListVisitedPagesFromSearchEngineQueryOptions) ([]api.VisitedPageFromSearchEngineQuery, error) {
+	profilePath, err := o.profilePath(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.ListVisitedPagesFromSearchEngineQuery(profilePath, options)
+}
+
+func (o *Chromium) ListVisitedPagesFromSourceRepos(profileName string, options api.ListVisitedPagesFromSourceReposOptions) ([]api.VisitedPageFromSourceRepos, error) {
+	profilePath, err := o.profilePath(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.ListVisitedPagesFromSourceRepos(profilePath, options)
+}
+
+// Profiles returns the profiles of all the installs of the browser.
+// The IDs of the profiles of the Flatpak and Snap installs are suffixed with the name of the install.
+func (o *Chromium) Profiles() ([]api.Profile, error) {
+	var result []api.Profile
+	var lastErr error
+	found := false
+	installs, err := o.installs()
+	if err != nil {
+		return nil, err
+	}
+	for _, install := range installs {
+		localState, err := files.ReadLocalState(install.userDataDirectory)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				log.Debug("browser install not found", "browser", o.Name(), "install", install.name, "path", install.userDataDirectory)
+			} else {
+				log.Warn("unable to read browser install", "browser", o.Name(), "install", install.name, "path", install.userDataDirectory, "error", err)
+			}
+			lastErr = err
+			continue
+		}
+		found = true
+
+		profileDirs := localState.Profile.ProfilesOrder
+		if len(profileDirs) == 0 {
+			profileDirs = slices.Sorted(maps.Keys(localState.Profile.InfoCache))
+		}
+		lastUsed := localState.Profile.LastUsed
+		if lastUsed == "" {
+			lastUsed = defaultProfile
+		}
+		if o.vendor.ProfileInRoot {
+			profileDirs = []string{defaultProfile}
 		}
+		for _, profileDir := range profileDirs {
+			info := localState.Profile.InfoCache[profileDir]
+			p := api.Profile{
+				ID:       profileDir,
+				Name:     info.Name,
+				Path:     filepath.Join(install.userDataDirectory, profileDir),
+				Default:  profileDir == lastUsed,
+				Account:  info.UserName,
+				LastUsed: info.ActiveTime.Time,
+				Install:  install.name,
+			}
+			if p.Name == "" {
+				p.Name = profileDir
+			}
+			if o.vendor.ProfileInRoot {
+				p.Path = install.userDataDirectory
+			}
+			if p.LastUsed.IsZero() {
+				p.LastUsed = system.LastModified(filepath.Join(p.Path, "History"))
+			}
+			if install.name != "" {
+				p.ID = fmt.Sprintf("%s (%s)", profileDir, install.name)
+			}
+			result = append(result, p)
+		}
+	}
+	if !found {
+		return nil, lastErr
+	}
+	return result, nil
+}
+
+// installs returns the locations of the user data of the browser,
+// or the directories configured for the browser
+func (o *Chromium) installs() ([]install, error) {
+	if dirs := system.ConfiguredDataDirectories(o.Name()); len(dirs) > 0 {
+		var result []install
+		for _, dir := range dirs {
+			result = append(result, install{name: dir.Name, userDataDirectory: dir.Path})
+		}
+		return result, nil
+	}
+	platform := system.Native()
+	if o.wsl {
+		var ok bool
+		if platform, ok = system.WSL(); !ok {
+			return nil, errors.New("not running in WSL")
+		}
+	}
+	installs := o.vendor.installs(platform)
+	if len(installs) == 0 {
+		return nil, fmt.Errorf("%s is not available on %s", o.Name(), platform.Os)
+	}
+	return installs, nil
+}
+
+// profilePath returns the directory containing the files of the profile
+func (o *Chromium) profilePath(profileName string) (string, error) {
+	profile, err := o.Profile(profileName)
+	if err != nil {
+		return "", err
 	}
-	return nil, fmt.Errorf("profile %s not found", profileName)
+	return profile.Path, nil
 }
 
-func (o *Chrome) SearchEngineQueries(profileName string, options api.SearchEngineOptions) ([]api.SearchEngineQuery, error) {
+// Profile returns the profile of the browser whose ID is profileName.
+// It is shared with the providers built on the Chromium provider, to resolve the profiles the same way.
+func (o *Chromium) Profile(profileName string) (api.Profile, error) {
 	profiles, err := o.Profiles()
 	if err != nil {
-		return nil, err
+		return api.Profile{}, err
 	}
 	for _, profile := range profiles {
-		if profile == profileName {
-			return files.SearchEngineQueries(profile, options)
+		if profile.ID == profileName {
+			return profile, nil
 		}
 	}
-	return nil, fmt.Errorf("profile %s not found", profileName)
+	return api.Profile{}, fmt.Errorf("profile %s not found", profileName)
 }
 
-func (o *Chrome) ListVisitedPagesFromSearchEngineQuery(profileName string, options api.ListVisitedPagesFromSearchEngineQueryOptions) ([]api.VisitedPageFromSearchEngineQuery, error) {
-	profiles, err := o.Profiles()
+var _ api.OpenTabsReader = &Chromium{}
+
+// ListOpenTabs returns the tabs open in the browser, read over the DevTools protocol when an endpoint is configured
+// or when the browser is running with `--remote-debugging-port`, or else from the session files of the profile.
+// As the browser lists the tabs of all its open profiles over the DevTools protocol,
+// the DevTools endpoint is only used when the user data directory contains a single profile.
+func (o *Chromium) ListOpenTabs(profileName string, options api.ListOpenTabsOptions) ([]api.Tab, error) {
+	profile, err := o.Profile(profileName)
 	if err != nil {
 		return nil, err
 	}
-	for _, profile := range profiles {
-		if profile == profileName {
-			return files.ListVisitedPagesFromSearchEngineQuery(profile, options)
+	single, err := o.singleProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	if single && options.DevToolsEndpoint != "" {
+		return files.ListDevToolsTargets(options.DevToolsEndpoint)
+	}
+	var devToolsErr error
+	if single {
+		userDataDirectory := filepath.Dir(profile.Path)
+		if o.vendor.ProfileInRoot {
+			userDataDirectory = profile.Path
 		}
+		endpoint, err := files.ReadDevToolsActivePort(userDataDirectory)
+		switch {
+		case err == nil:
+			tabs, err := files.ListDevToolsTargets(endpoint)
+			if err == nil {
+				return tabs, nil
+			}
+			// the file is left behind when the browser exits abruptly
+			log.Debug("unable to reach the DevTools endpoint, reading the session files", "browser", o.Name(), "endpoint", endpoint, "error", err)
+			devToolsErr = err
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, err
+		}
+	}
+	session, err := files.ReadSession(profile.Path)
+	if err == nil {
+		return session.ListOpenTabs(), nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if devToolsErr != nil {
+		return nil, devToolsErr
 	}
-	return nil, fmt.Errorf("profile %s not found", profileName)
+	if !single {
+		return nil, fmt.Errorf("no session file found for profile %s, and the DevTools endpoint of %s cannot be used as it lists the tabs of all the profiles", profileName, o.Name())
+	}
+	return nil, fmt.Errorf("%s is not running with --remote-debugging-port, and no DevTools endpoint is configured for it", o.Name())
 }
 
-func (o *Chrome) ListVisitedPagesFromSourceRepos(profileName string, options api.ListVisitedPagesFromSourceReposOptions) ([]api.VisitedPageFromSourceRepos, error) {
+// singleProfile indicates that the profile is the only profile of its install
+func (o *Chromium) singleProfile(profile api.Profile) (bool, error) {
 	profiles, err := o.Profiles()
 	if err != nil {
-		return nil, err
+		return false, err
 	}
-	for _, profile := range profiles {
-		if profile == profileName {
-			return files.ListVisitedPagesFromSourceRepos(profile, options)
+	count := 0
+	for _, p := range profiles {
+		if p.Install == profile.Install {
+			count++
 		}
 	}
-	return nil, fmt.Errorf("profile %s not found", profileName)
+	return count == 1, nil
+}
+
+// ChromiumWithNotes is a provider for the browsers of the Chromium family letting users take notes (Vivaldi)
+type ChromiumWithNotes struct {
+	*Chromium
+}
+
+var _ api.NotesReader = &ChromiumWithNotes{}
+
+func (o *ChromiumWithNotes) ListNotes(profileName string, options api.ListNotesOptions) ([]api.Note, error) {
+	profilePath, err := o.profilePath(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.ListNotes(profilePath, options)
+}
+
+// ChromiumWithCollections is a provider for the browsers of the Chromium family letting users group saved pages in collections (Edge)
+type ChromiumWithCollections struct {
+	*Chromium
+}
+
+var _ api.CollectionsReader = &ChromiumWithCollections{}
+
+func (o *ChromiumWithCollections) ListCollections(profileName string, options api.ListCollectionsOptions) ([]api.Collection, error) {
+	profilePath, err := o.profilePath(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.ListCollections(profilePath, options)
+}
+
+// register registers a provider, with the optional capabilities supported by its vendor
+func register(provider *Chromium) {
+	switch {
+	case provider.vendor.Notes:
+		browsers.Register(&ChromiumWithNotes{Chromium: provider})
+	case provider.vendor.Collections:
+		browsers.Register(&ChromiumWithCollections{Chromium: provider})
+	default:
+		browsers.Register(provider)
+	}
 }
 
 func init() {
-	browsers.Register(instance)
+	for _, vendor := range Vendors {
+		register(New(vendor))
+		register(NewWSL(vendor))
+	}
 }
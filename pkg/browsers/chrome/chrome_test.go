@@ -0,0 +1,440 @@
+package chrome
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/test"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProfiles(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	configPath := filepath.Join(os.Getenv("HOME"), ".config")
+	system.WriteFile(filepath.Join(configPath, "BraveSoftware", "Brave-Browser", "Local State"), []byte(`{
+  "profile": {
+    "profiles_order": ["Default", "Profile 1"]
+  }
+}`), 0644)
+	system.WriteFile(filepath.Join(configPath, "opera", "Local State"), []byte(`{}`), 0644)
+	system.WriteFile(filepath.Join(configPath, "google-chrome-unstable", "Local State"), []byte(`{
+  "profile": {
+    "profiles_order": ["Default"]
+  }
+}`), 0644)
+
+	for _, tt := range []struct {
+		name      string
+		vendor    string
+		available bool
+		expected  []string
+	}{
+		{
+			name:      "profiles are read from Local State",
+			vendor:    "brave",
+			available: true,
+			expected:  []string{"Default", "Profile 1"},
+		},
+		{
+			name:      "single profile stored in the user data directory",
+			vendor:    "opera",
+			available: true,
+			expected:  []string{"Default"},
+		},
+		{
+			name:      "chrome channel installed",
+			vendor:    "chrome-dev",
+			available: true,
+			expected:  []string{"Default"},
+		},
+		{
+			name:      "chrome channel not installed",
+			vendor:    "chrome-beta",
+			available: false,
+		},
+		{
+			name:      "browser not installed",
+			vendor:    "vivaldi",
+			available: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var browser *Chromium
+			for _, vendor := range Vendors {
+				if vendor.Name == tt.vendor {
+					browser = New(vendor)
+				}
+			}
+			available, _ := browser.IsAvailable()
+			if available != tt.available {
+				t.Fatalf("expected available to be %v, got %v", tt.available, available)
+			}
+			if !available {
+				return
+			}
+			profiles, err := browser.Profiles()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !cmp.Equal(tt.expected, test.ProfileIDs(profiles)) {
+				t.Errorf("expected %v, got %v", tt.expected, test.ProfileIDs(profiles))
+			}
+		})
+	}
+}
+
+func TestProfilePath(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	configPath := filepath.Join(os.Getenv("HOME"), ".config")
+	system.WriteFile(filepath.Join(configPath, "microsoft-edge", "Local State"), []byte(`{
+  "profile": {
+    "profiles_order": ["Default"]
+  }
+}`), 0644)
+	system.WriteFile(filepath.Join(configPath, "opera", "Local State"), []byte(`{}`), 0644)
+
+	edge := New(Vendor{Name: "edge", Linux: []string{"microsoft-edge"}})
+	path, err := edge.profilePath("Default")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := filepath.Join(configPath, "microsoft-edge", "Default"); path != expected {
+		t.Errorf("expected %s, got %s", expected, path)
+	}
+	if _, err = edge.profilePath("Profile 1"); err == nil {
+		t.Errorf("expected error for unknown profile")
+	}
+
+	opera := New(Vendor{Name: "opera", Linux: []string{"opera"}, ProfileInRoot: true})
+	path, err = opera.profilePath("Default")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := filepath.Join(configPath, "opera"); path != expected {
+		t.Errorf("expected %s, got %s", expected, path)
+	}
+}
+
+func TestProfilesFromSandboxedInstalls(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	home := os.Getenv("HOME")
+	localState := []byte(`{
+  "profile": {
+    "profiles_order": ["Default"]
+  }
+}`)
+	system.WriteFile(filepath.Join(home, ".config", "chromium", "Local State"), localState, 0644)
+	system.WriteFile(filepath.Join(home, ".var", "app", "org.chromium.Chromium", "config", "chromium", "Local State"), localState, 0644)
+	system.WriteFile(filepath.Join(home, "snap", "chromium", "common", "chromium", "Local State"), localState, 0644)
+
+	chromium := New(Vendor{
+		Name:    "chromium",
+		Linux:   []string{"chromium"},
+		Flatpak: []string{"org.chromium.Chromium", "config", "chromium"},
+		Snap:    []string{"chromium", "common", "chromium"},
+	})
+	profiles, err := chromium.Profiles()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []string{"Default", "Default (flatpak)", "Default (snap)"}
+	if !cmp.Equal(expected, test.ProfileIDs(profiles)) {
+		t.Errorf("expected %v, got %v", expected, test.ProfileIDs(profiles))
+	}
+
+	path, err := chromium.profilePath("Default (snap)")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := filepath.Join(home, "snap", "chromium", "common", "chromium", "Default"); path != expected {
+		t.Errorf("expected %s, got %s", expected, path)
+	}
+}
+
+func TestProfilesMetadata(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	configPath := filepath.Join(os.Getenv("HOME"), ".config")
+	system.WriteFile(filepath.Join(configPath, "google-chrome", "Local State"), []byte(`{
+  "profile": {
+    "last_used": "Profile 3",
+    "profiles_order": ["Default", "Profile 3"],
+    "info_cache": {
+      "Default": {
+        "name": "Personal",
+        "user_name": "",
+        "active_time": 1700000000.5
+      },
+      "Profile 3": {
+        "name": "Work",
+        "user_name": "jane@example.com",
+        "active_time": 1745826734
+      }
+    }
+  }
+}`), 0644)
+
+	chrome := New(Vendor{Name: "chrome", Linux: []string{"google-chrome"}})
+	profiles, err := chrome.Profiles()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []api.Profile{
+		{
+			ID:       "Default",
+			Name:     "Personal",
+			Path:     filepath.Join(configPath, "google-chrome", "Default"),
+			LastUsed: time.Unix(1700000000, 500000000),
+		},
+		{
+			ID:       "Profile 3",
+			Name:     "Work",
+			Path:     filepath.Join(configPath, "google-chrome", "Profile 3"),
+			Default:  true,
+			Account:  "jane@example.com",
+			LastUsed: time.Unix(1745826734, 0),
+		},
+	}
+	if diff := cmp.Diff(expected, profiles); diff != "" {
+		t.Errorf("profiles mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUserDataDirectoryOnWindows(t *testing.T) {
+	system.Os = "windows"
+	defer func() { system.Os = "linux" }()
+	t.Setenv("APPDATA", filepath.Join("/windows", "Roaming"))
+	t.Setenv("LOCALAPPDATA", filepath.Join("/windows", "Local"))
+
+	for vendorName, expected := range map[string]string{
+		"chrome": filepath.Join("/windows", "Local", "Google", "Chrome", "User Data"),
+		"opera":  filepath.Join("/windows", "Roaming", "Opera Software", "Opera Stable"),
+	} {
+		for _, vendor := range Vendors {
+			if vendor.Name == vendorName && vendor.userDataDirectory(system.Native()) != expected {
+				t.Errorf("%s: expected %s, got %s", vendorName, expected, vendor.userDataDirectory(system.Native()))
+			}
+		}
+	}
+}
+
+func TestConfiguredDataDirectories(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	system.DataDirectories = map[string][]string{
+		"chromium": {"/portable/chromium/profile", "~/chromium-work"},
+	}
+	defer func() { system.DataDirectories = nil }()
+	localState := []byte(`{
+  "profile": {
+    "profiles_order": ["Default"]
+  }
+}`)
+	// default location is ignored
+	system.WriteFile(filepath.Join(os.Getenv("HOME"), ".config", "chromium", "Local State"), localState, 0644)
+	system.WriteFile(filepath.Join("/portable", "chromium", "profile", "Local State"), localState, 0644)
+	system.WriteFile(filepath.Join(os.Getenv("HOME"), "chromium-work", "Local State"), localState, 0644)
+
+	chromium := New(Vendor{Name: "chromium", Linux: []string{"chromium"}})
+	profiles, err := chromium.Profiles()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	workDir := filepath.Join(os.Getenv("HOME"), "chromium-work")
+	expected := []string{"Default (/portable/chromium/profile)", "Default (" + workDir + ")"}
+	if !cmp.Equal(expected, test.ProfileIDs(profiles)) {
+		t.Errorf("expected %v, got %v", expected, test.ProfileIDs(profiles))
+	}
+	if expected := filepath.Join(workDir, "Default"); profiles[1].Path != expected {
+		t.Errorf("expected %s, got %s", expected, profiles[1].Path)
+	}
+}
+
+func TestWSL(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	t.Setenv("WSL_DISTRO_NAME", "")
+	t.Setenv("USER", "jane")
+	procVersionFile := system.ProcVersionFile
+	system.ProcVersionFile = filepath.Join(t.TempDir(), "version")
+	t.Cleanup(func() { system.ProcVersionFile = procVersionFile })
+	localState := []byte(`{
+  "profile": {
+    "profiles_order": ["Default"]
+  }
+}`)
+	system.WriteFile(filepath.Join(os.Getenv("HOME"), ".config", "google-chrome", "Local State"), localState, 0644)
+	system.WriteFile("/mnt/c/Users/jane/AppData/Local/Google/Chrome/User Data/Local State", localState, 0644)
+	system.FileSystem.MkdirAll("/mnt/c/Users/Public", 0755)
+
+	vendor := Vendor{Name: "chrome", Linux: []string{"google-chrome"}, Windows: []string{"Google", "Chrome", "User Data"}}
+	windows := NewWSL(vendor)
+	if windows.Name() != "chrome (windows)" {
+		t.Errorf("expected chrome (windows), got %s", windows.Name())
+	}
+
+	t.Run("not in WSL", func(t *testing.T) {
+		if available, _ := windows.IsAvailable(); available {
+			t.Error("expected the windows browser not to be available")
+		}
+	})
+
+	os.WriteFile(system.ProcVersionFile, []byte("Linux version 5.15.167.4-microsoft-standard-WSL2"), 0644)
+
+	t.Run("in WSL", func(t *testing.T) {
+		profiles, err := windows.Profiles()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		expected := filepath.Join("/mnt/c/Users/jane/AppData/Local/Google/Chrome/User Data", "Default")
+		if len(profiles) != 1 || profiles[0].Path != expected {
+			t.Errorf("expected a single profile in %s, got %v", expected, profiles)
+		}
+		linuxProfiles, err := New(vendor).Profiles()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		expected = filepath.Join(os.Getenv("HOME"), ".config", "google-chrome", "Default")
+		if len(linuxProfiles) != 1 || linuxProfiles[0].Path != expected {
+			t.Errorf("expected a single profile in %s, got %v", expected, linuxProfiles)
+		}
+	})
+}
+
+func TestListOpenTabs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": "A1", "title": "Example Domain", "type": "page", "url": "https://example.com/"}]`))
+	}))
+	defer server.Close()
+	port := server.URL[strings.LastIndex(server.URL, ":")+1:]
+
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	configPath := filepath.Join(os.Getenv("HOME"), ".config")
+	localState := []byte(`{
+  "profile": {
+    "profiles_order": ["Default"]
+  }
+}`)
+	system.WriteFile(filepath.Join(configPath, "chromium", "Local State"), localState, 0644)
+	system.WriteFile(filepath.Join(configPath, "google-chrome", "Local State"), localState, 0644)
+	system.WriteFile(filepath.Join(configPath, "google-chrome", "DevToolsActivePort"), []byte(port+"\n/devtools/browser/0a1b2c3d\n"), 0644)
+
+	expected := []api.Tab{{Title: "Example Domain", URL: "https://example.com/", Type: "page"}}
+	session := []byte("SNSS\x01\x00\x00\x00" +
+		"\x09\x00\x00\x01\x00\x00\x00\x02\x00\x00\x00" + // tab 2 in window 1
+		"\x39\x00\x06\x34\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00" + // navigation 0 of tab 2
+		"\x14\x00\x00\x00https://example.com/" +
+		"\x07\x00\x00\x00E\x00x\x00a\x00m\x00p\x00l\x00e\x00\x00\x00")
+	expectedFromSession := []api.Tab{{Title: "Example", URL: "https://example.com/", Window: 1}}
+
+	t.Run("endpoint from DevToolsActivePort", func(t *testing.T) {
+		tabs, err := New(Vendor{Name: "chrome", Linux: []string{"google-chrome"}}).ListOpenTabs("Default", api.ListOpenTabsOptions{})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if diff := cmp.Diff(expected, tabs); diff != "" {
+			t.Errorf("tabs mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	chromium := New(Vendor{Name: "chromium", Linux: []string{"chromium"}})
+
+	t.Run("not running with remote debugging", func(t *testing.T) {
+		_, err := chromium.ListOpenTabs("Default", api.ListOpenTabsOptions{})
+		if expected := "chromium is not running with --remote-debugging-port, and no DevTools endpoint is configured for it"; err == nil || err.Error() != expected {
+			t.Errorf("expected error %q, got %v", expected, err)
+		}
+	})
+
+	t.Run("tabs from session files", func(t *testing.T) {
+		system.WriteFile(filepath.Join(configPath, "chromium", "Default", "Sessions", "Session_13370000000000000"), session, 0644)
+		defer system.FileSystem.RemoveAll(filepath.Join(configPath, "chromium", "Default", "Sessions"))
+		tabs, err := chromium.ListOpenTabs("Default", api.ListOpenTabsOptions{})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if diff := cmp.Diff(expectedFromSession, tabs); diff != "" {
+			t.Errorf("tabs mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("live tabs preferred to the session files", func(t *testing.T) {
+		system.WriteFile(filepath.Join(configPath, "google-chrome", "Default", "Sessions", "Session_13370000000000000"), session, 0644)
+		defer system.FileSystem.RemoveAll(filepath.Join(configPath, "google-chrome", "Default", "Sessions"))
+		tabs, err := New(Vendor{Name: "chrome", Linux: []string{"google-chrome"}}).ListOpenTabs("Default", api.ListOpenTabsOptions{})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if diff := cmp.Diff(expected, tabs); diff != "" {
+			t.Errorf("tabs mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("session files when DevToolsActivePort is left behind", func(t *testing.T) {
+		stopped := httptest.NewServer(http.NotFoundHandler())
+		stopped.Close()
+		stoppedPort := stopped.URL[strings.LastIndex(stopped.URL, ":")+1:]
+		system.WriteFile(filepath.Join(configPath, "chromium", "DevToolsActivePort"), []byte(stoppedPort+"\n/devtools/browser/0a1b2c3d\n"), 0644)
+		defer system.FileSystem.Remove(filepath.Join(configPath, "chromium", "DevToolsActivePort"))
+
+		if _, err := chromium.ListOpenTabs("Default", api.ListOpenTabsOptions{}); err == nil {
+			t.Errorf("expected an error without session files")
+		}
+
+		system.WriteFile(filepath.Join(configPath, "chromium", "Default", "Sessions", "Session_13370000000000000"), session, 0644)
+		defer system.FileSystem.RemoveAll(filepath.Join(configPath, "chromium", "Default", "Sessions"))
+		tabs, err := chromium.ListOpenTabs("Default", api.ListOpenTabsOptions{})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if diff := cmp.Diff(expectedFromSession, tabs); diff != "" {
+			t.Errorf("tabs mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("configured endpoint", func(t *testing.T) {
+		tabs, err := chromium.ListOpenTabs("Default", api.ListOpenTabsOptions{DevToolsEndpoint: server.URL})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if diff := cmp.Diff(expected, tabs); diff != "" {
+			t.Errorf("tabs mismatch (-want +got):\n%s", diff)
+		}
+	})
+	t.Run("session files when the browser has several profiles", func(t *testing.T) {
+		system.WriteFile(filepath.Join(configPath, "google-chrome", "Local State"), []byte(`{
+  "profile": {
+    "profiles_order": ["Default", "Profile 1"]
+  }
+}`), 0644)
+		defer system.WriteFile(filepath.Join(configPath, "google-chrome", "Local State"), localState, 0644)
+		chrome := New(Vendor{Name: "chrome", Linux: []string{"google-chrome"}})
+
+		_, err := chrome.ListOpenTabs("Default", api.ListOpenTabsOptions{DevToolsEndpoint: server.URL})
+		if expected := "no session file found for profile Default, and the DevTools endpoint of chrome cannot be used as it lists the tabs of all the profiles"; err == nil || err.Error() != expected {
+			t.Errorf("expected error %q, got %v", expected, err)
+		}
+
+		system.WriteFile(filepath.Join(configPath, "google-chrome", "Default", "Sessions", "Session_13370000000000000"), session, 0644)
+		defer system.FileSystem.RemoveAll(filepath.Join(configPath, "google-chrome", "Default", "Sessions"))
+		tabs, err := chrome.ListOpenTabs("Default", api.ListOpenTabsOptions{DevToolsEndpoint: server.URL})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if diff := cmp.Diff(expectedFromSession, tabs); diff != "" {
+			t.Errorf("tabs mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -35,9 +35,9 @@ type BookmarkEntry struct {
 	URL          string              `json:"url,omitempty"` // for url type only
 }
 
-// ParseBookmarks returns the bookmarks in a Chrome profile.
-func ListBookmarks(profile string) ([]api.BookMark, error) {
-	filename := filepath.Join(getUserDataDirecory(), profile, "Bookmarks")
+// ListBookmarks returns the bookmarks in a Chrome profile.
+func ListBookmarks(profilePath string) ([]api.BookMark, error) {
+	filename := filepath.Join(profilePath, "Bookmarks")
 	data, err := system.ReadFile(filename)
 	if err != nil {
 		return nil, err
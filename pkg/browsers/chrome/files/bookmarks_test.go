@@ -8,11 +8,10 @@ import (
 
 	"github.com/feloy/browsers-mcp-server/pkg/system"
 	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
-	"github.com/spf13/afero"
 )
 
 func TestListBookmarks(t *testing.T) {
-	system.FileSystem = afero.NewMemMapFs()
+	globaltest.MemFileSystem(t)
 	system.Os = "darwin"
 	basePath := filepath.Join(os.Getenv("HOME"), "Library", "Application Support", "Google", "Chrome", "Profile1")
 	system.WriteFile(filepath.Join(basePath, "Bookmarks"), []byte(`{
@@ -33,7 +32,7 @@ func TestListBookmarks(t *testing.T) {
 }
 `), 0644)
 
-	bookmarks, err := ListBookmarks("Profile1")
+	bookmarks, err := ListBookmarks(basePath)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -0,0 +1,102 @@
+package files
+
+import (
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+)
+
+// ListCollections returns the collections of an Edge profile, with their items,
+// from the `Collections/collectionsSQLite` database.
+func ListCollections(profilePath string, options api.ListCollectionsOptions) ([]api.Collection, error) {
+	log.Debug("collections", "profilePath", profilePath, "options", options)
+
+	filename := filepath.Join(profilePath, "Collections", "collectionsSQLite")
+	db, err := getDb(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT
+    collections.id,
+    collections.title,
+    collections.date_created,
+    collections.date_modified,
+    items.title,
+    items.source,
+    items.text_content,
+    items.type,
+    items.date_created
+  FROM collections
+  LEFT JOIN collections_items_relationship ON collections_items_relationship.parent_id = collections.id
+  LEFT JOIN items ON items.id = collections_items_relationship.item_id AND items.is_marked_for_deletion = 0
+  WHERE collections.is_marked_for_deletion = 0
+  ORDER BY collections.position, collections_items_relationship.position`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []api.Collection{}
+	var currentID string
+	for rows.Next() {
+		var id string
+		var collection api.Collection
+		var collectionCreated, collectionModified float64
+		var title, source, textContent, itemType sql.NullString
+		var itemCreated sql.NullFloat64
+		if err = rows.Scan(&id, &collection.Title, &collectionCreated, &collectionModified, &title, &source, &textContent, &itemType, &itemCreated); err != nil {
+			return nil, err
+		}
+		if options.Collection != "" && !strings.EqualFold(collection.Title, options.Collection) {
+			continue
+		}
+		if id != currentID {
+			currentID = id
+			collection.DateAdded = fromCollectionsDbDate(collectionCreated)
+			collection.DateModified = fromCollectionsDbDate(collectionModified)
+			collection.Items = []api.CollectionItem{}
+			result = append(result, collection)
+		}
+		if !itemType.Valid {
+			// empty collection, or deleted item
+			continue
+		}
+		item := api.CollectionItem{
+			Title:     title.String,
+			URL:       sourceURL(source.String),
+			DateAdded: fromCollectionsDbDate(itemCreated.Float64),
+		}
+		if itemType.String == "note" {
+			item.Note = textContent.String
+		}
+		last := &result[len(result)-1]
+		last.Items = append(last.Items, item)
+	}
+	return result, rows.Err()
+}
+
+// sourceURL returns the URL of the page an item was saved from, declared in the JSON source of the item
+func sourceURL(source string) string {
+	var value struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal([]byte(source), &value); err != nil {
+		return ""
+	}
+	return value.URL
+}
+
+// fromCollectionsDbDate converts the dates of the collections database, in milliseconds since the Unix epoch
+func fromCollectionsDbDate(dbDate float64) time.Time {
+	if dbDate == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(dbDate)).UTC()
+}
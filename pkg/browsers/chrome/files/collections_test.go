@@ -0,0 +1,95 @@
+package files
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+// collectionsDb is the Collections database of an Edge profile
+var collectionsDb = globaltest.Fixture{
+	Path: filepath.Join("/profiles/Default", "Collections", "collectionsSQLite"),
+	Schema: []string{
+		`CREATE TABLE collections (id TEXT PRIMARY KEY NOT NULL, date_created REAL NOT NULL, date_modified REAL NOT NULL, title TEXT NOT NULL, position INTEGER NOT NULL, is_marked_for_deletion INTEGER DEFAULT 0)`,
+		`CREATE TABLE items (id TEXT PRIMARY KEY NOT NULL, date_created REAL NOT NULL, date_modified REAL NOT NULL, title TEXT, source TEXT, text_content TEXT, type TEXT NOT NULL, is_marked_for_deletion INTEGER DEFAULT 0)`,
+		`CREATE TABLE collections_items_relationship (item_id TEXT NOT NULL, parent_id TEXT NOT NULL, position INTEGER NOT NULL)`,
+	},
+}
+
+func TestListCollections(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	profilePath := "/profiles/Default"
+	collectionsDb.Write(t,
+		// 2025-04-28T07:52:14Z, 2025-04-29T07:52:14Z
+		`INSERT INTO collections (id, date_created, date_modified, title, position, is_marked_for_deletion) VALUES
+			('c1', 1745826734000, 1745913134000, 'Trip to Lisbon', 1, 0),
+			('c2', 1745826734000, 1745826734000, 'Empty', 2, 0),
+			('c3', 1745826734000, 1745826734000, 'Deleted', 3, 1)`,
+		`INSERT INTO items (id, date_created, date_modified, title, source, text_content, type, is_marked_for_deletion) VALUES
+			('i1', 1745826734000, 1745826734000, 'Hotels in Lisbon', '{"url":"https://hotels.example.com/lisbon","websiteName":"hotels"}', 'text of the page', 'website', 0),
+			('i2', 1745913134000, 1745913134000, NULL, NULL, 'Book the tram tour', 'note', 0),
+			('i3', 1745913134000, 1745913134000, 'Removed', '{"url":"https://removed.example.com"}', NULL, 'website', 1)`,
+		`INSERT INTO collections_items_relationship (item_id, parent_id, position) VALUES
+			('i2', 'c1', 2),
+			('i1', 'c1', 1),
+			('i3', 'c1', 3)`,
+	)
+
+	lisbon := api.Collection{
+		Title:        "Trip to Lisbon",
+		DateAdded:    time.Date(2025, 4, 28, 7, 52, 14, 0, time.UTC),
+		DateModified: time.Date(2025, 4, 29, 7, 52, 14, 0, time.UTC),
+		Items: []api.CollectionItem{
+			{
+				Title:     "Hotels in Lisbon",
+				URL:       "https://hotels.example.com/lisbon",
+				DateAdded: time.Date(2025, 4, 28, 7, 52, 14, 0, time.UTC),
+			},
+			{
+				Note:      "Book the tram tour",
+				DateAdded: time.Date(2025, 4, 29, 7, 52, 14, 0, time.UTC),
+			},
+		},
+	}
+	empty := api.Collection{
+		Title:        "Empty",
+		DateAdded:    time.Date(2025, 4, 28, 7, 52, 14, 0, time.UTC),
+		DateModified: time.Date(2025, 4, 28, 7, 52, 14, 0, time.UTC),
+		Items:        []api.CollectionItem{},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		options  api.ListCollectionsOptions
+		expected []api.Collection
+	}{
+		{
+			name:     "all collections, except the deleted ones",
+			expected: []api.Collection{lisbon, empty},
+		},
+		{
+			name:     "collection by title",
+			options:  api.ListCollectionsOptions{Collection: "trip to lisbon"},
+			expected: []api.Collection{lisbon},
+		},
+		{
+			name:     "unknown collection",
+			options:  api.ListCollectionsOptions{Collection: "Deleted"},
+			expected: []api.Collection{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			collections, err := ListCollections(profilePath, tt.options)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, collections); diff != "" {
+				t.Errorf("collections mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,12 +2,13 @@ package files
 
 import (
 	"database/sql"
-	"fmt"
 	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
 )
 
 func getDb(filename string) (*sql.DB, error) {
-	return sql.Open("sqlite", fmt.Sprintf("file:%s?mode=ro&nolock=1", filename))
+	return system.OpenDatabase(filename)
 }
 
 func fromDbDate(dbDate int64) time.Time {
@@ -16,3 +17,44 @@ func fromDbDate(dbDate int64) time.Time {
 func toDbDate(d time.Time) int64 {
 	return (d.Unix() + 11_644_473_600) * 1_000_000
 }
+
+// historyQueries are the queries run on the History database, adapted to the version of its schema
+type historyQueries struct {
+	searchEngineQueries               string
+	visitedPagesFromSearchEngineQuery string
+	visitedPagesFromSourceRepos       string
+}
+
+// historySchema lists the versions of the History database supported, declared in its `meta` table
+var historySchema = system.Schema[historyQueries]{
+	Database:     "chrome History",
+	VersionQuery: `SELECT value FROM meta WHERE key = 'version'`,
+	Ranges: []system.SchemaRange[historyQueries]{
+		{
+			// version 17 moved the times of macOS and Linux to the Windows epoch, read by fromDbDate
+			MinVersion: 17,
+			MaxVersion: 55,
+			Queries: historyQueries{
+				searchEngineQueries:               searchEngineQueriesSQL,
+				visitedPagesFromSearchEngineQuery: visitedPagesFromSearchEngineQuerySQL,
+				visitedPagesFromSourceRepos:       visitedPagesFromSourceReposSQL,
+			},
+		},
+		{
+			// the opener_visit column of visits is only read from version 56,
+			// the older versions are read with from_visit only
+			MinVersion: 56,
+			Queries: historyQueries{
+				searchEngineQueries:               searchEngineQueriesSQL,
+				visitedPagesFromSearchEngineQuery: visitedPagesFromSearchEngineQueryWithOpenerSQL,
+				visitedPagesFromSourceRepos:       visitedPagesFromSourceReposSQL,
+			},
+		},
+	},
+	// MaxTestedVersion is the version of the History fixture of the tests
+	MaxTestedVersion: 70,
+}
+
+func getHistoryDb(filename string) (*system.SchemaDatabase[historyQueries], error) {
+	return historySchema.Open(filename)
+}
@@ -0,0 +1,70 @@
+package files
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+// DevToolsTarget is a target listed by the `/json/list` endpoint of the DevTools protocol
+type DevToolsTarget struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+var devToolsClient = &http.Client{Timeout: 5 * time.Second}
+
+// ReadDevToolsActivePort returns the DevTools endpoint of a browser running with `--remote-debugging-port`,
+// from the `DevToolsActivePort` file the browser writes in its user data directory.
+func ReadDevToolsActivePort(userDataDirectory string) (string, error) {
+	data, err := system.ReadFile(filepath.Join(userDataDirectory, "DevToolsActivePort"))
+	if err != nil {
+		return "", err
+	}
+	// first line is the port, second line is the path of the browser websocket
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	if !scanner.Scan() {
+		return "", fmt.Errorf("empty DevToolsActivePort file in %s", userDataDirectory)
+	}
+	port, err := strconv.Atoi(strings.TrimSpace(scanner.Text()))
+	if err != nil || port <= 0 {
+		return "", fmt.Errorf("invalid port %q in DevToolsActivePort file in %s", scanner.Text(), userDataDirectory)
+	}
+	return fmt.Sprintf("http://127.0.0.1:%d", port), nil
+}
+
+// ListDevToolsTargets returns the targets (tabs, service workers, ...) of a running browser, from its DevTools endpoint
+func ListDevToolsTargets(endpoint string) ([]api.Tab, error) {
+	response, err := devToolsClient.Get(strings.TrimSuffix(endpoint, "/") + "/json/list")
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s from %s", response.Status, endpoint)
+	}
+	var targets []DevToolsTarget
+	if err = json.NewDecoder(response.Body).Decode(&targets); err != nil {
+		return nil, err
+	}
+	result := []api.Tab{}
+	for _, target := range targets {
+		result = append(result, api.Tab{
+			Title: target.Title,
+			URL:   target.URL,
+			Type:  target.Type,
+		})
+	}
+	return result, nil
+}
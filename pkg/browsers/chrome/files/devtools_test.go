@@ -0,0 +1,72 @@
+package files
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListDevToolsTargets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/list" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`[ {
+   "description": "",
+   "devtoolsFrontendUrl": "/devtools/inspector.html?ws=localhost:9222/devtools/page/A1",
+   "id": "A1",
+   "title": "Example Domain",
+   "type": "page",
+   "url": "https://example.com/",
+   "webSocketDebuggerUrl": "ws://localhost:9222/devtools/page/A1"
+}, {
+   "id": "B2",
+   "title": "Service Worker https://app.example.com/sw.js",
+   "type": "service_worker",
+   "url": "https://app.example.com/sw.js"
+} ]`))
+	}))
+	defer server.Close()
+
+	tabs, err := ListDevToolsTargets(server.URL + "/")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []api.Tab{
+		{Title: "Example Domain", URL: "https://example.com/", Type: "page"},
+		{Title: "Service Worker https://app.example.com/sw.js", URL: "https://app.example.com/sw.js", Type: "service_worker"},
+	}
+	if diff := cmp.Diff(expected, tabs); diff != "" {
+		t.Errorf("tabs mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err = ListDevToolsTargets(server.URL + "/other"); err == nil {
+		t.Error("expected an error for an invalid endpoint")
+	}
+}
+
+func TestReadDevToolsActivePort(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.WriteFile("/chrome/DevToolsActivePort", []byte("9222\n/devtools/browser/0a1b2c3d\n"), 0644)
+	system.WriteFile("/invalid/DevToolsActivePort", []byte("port\n"), 0644)
+
+	endpoint, err := ReadDevToolsActivePort("/chrome")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if endpoint != "http://127.0.0.1:9222" {
+		t.Errorf("expected http://127.0.0.1:9222, got %s", endpoint)
+	}
+	if _, err = ReadDevToolsActivePort("/invalid"); err == nil {
+		t.Error("expected an error for an invalid port")
+	}
+	if _, err = ReadDevToolsActivePort("/missing"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"path/filepath"
 
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/chrome/files/fields"
 	"github.com/feloy/browsers-mcp-server/pkg/system"
 )
 
@@ -13,10 +14,22 @@ type LocalState struct {
 
 type LocalStateProfile struct {
 	ProfilesOrder []string `json:"profiles_order"`
+	// LastUsed is the directory of the profile used last
+	LastUsed string `json:"last_used"`
+	// InfoCache contains the information about the profiles, indexed by their directories
+	InfoCache map[string]ProfileInfo `json:"info_cache"`
 }
 
-func ReadLocalState() (*LocalState, error) {
-	path := filepath.Join(getUserDataDirecory(), "Local State")
+type ProfileInfo struct {
+	Name string `json:"name"`
+	// UserName is the email of the account the profile is signed in with
+	UserName   string         `json:"user_name"`
+	ActiveTime fields.UnixSec `json:"active_time"`
+}
+
+// ReadLocalState reads the `Local State` file of a user data directory.
+func ReadLocalState(userDataDirectory string) (*LocalState, error) {
+	path := filepath.Join(userDataDirectory, "Local State")
 	jsonData, err := system.ReadFile(path)
 	if err != nil {
 		return nil, err
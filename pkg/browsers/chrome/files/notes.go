@@ -0,0 +1,110 @@
+package files
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/chrome/files/fields"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+// Notes contains Vivaldi notes information.
+type Notes struct {
+	Children []NoteEntry `json:"children"`
+}
+
+type NoteEntry struct {
+	Attachments  []json.RawMessage   `json:"attachments,omitempty"` // for note type only
+	Children     []NoteEntry         `json:"children,omitempty"`    // for folder type only
+	Content      string              `json:"content"`
+	DateAdded    fields.QuotedChrome `json:"date_added"`
+	DateModified fields.QuotedChrome `json:"date_modified,omitempty"`
+	ID           string              `json:"id"`
+	Subject      string              `json:"subject"`
+	Type         string              `json:"type"` // "note", "folder", "trash" or "separator"
+	URL          string              `json:"url,omitempty"`
+}
+
+// ListNotes returns the notes in a Vivaldi profile, except the notes in the trash.
+func ListNotes(profilePath string, options api.ListNotesOptions) ([]api.Note, error) {
+	filename := filepath.Join(profilePath, "Notes")
+	data, err := system.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var treeNotes Notes
+	err = json.Unmarshal(data, &treeNotes)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []api.Note{}
+	for _, entry := range treeNotes.Children {
+		for _, note := range flatNotesRec(entry, []string{}) {
+			if matchNote(note, options) {
+				result = append(result, note)
+			}
+		}
+	}
+	return result, nil
+}
+
+func flatNotesRec(entry NoteEntry, folder []string) []api.Note {
+	result := []api.Note{}
+	if entry.Type == "folder" {
+		for _, child := range entry.Children {
+			result = append(result, flatNotesRec(child, append(folder[:len(folder):len(folder)], entry.Subject))...)
+		}
+	}
+	if entry.Type == "note" {
+		result = append(result, api.Note{
+			Title:        entry.Subject,
+			Content:      entry.Content,
+			URL:          entry.URL,
+			Folder:       folder,
+			Attachments:  len(entry.Attachments),
+			DateAdded:    entry.DateAdded.Time,
+			DateModified: entry.DateModified.Time,
+		})
+	}
+	return result
+}
+
+func matchNote(note api.Note, options api.ListNotesOptions) bool {
+	if options.Folder != "" {
+		folder := strings.ToLower(strings.Join(note.Folder, "/"))
+		wanted := strings.ToLower(strings.Trim(options.Folder, "/"))
+		if folder != wanted && !strings.HasPrefix(folder, wanted+"/") {
+			return false
+		}
+	}
+	if options.Text != "" {
+		text := strings.ToLower(options.Text)
+		if !strings.Contains(strings.ToLower(note.Title), text) &&
+			!strings.Contains(strings.ToLower(note.Content), text) &&
+			!strings.Contains(strings.ToLower(note.URL), text) {
+			return false
+		}
+	}
+	modified := note.DateModified
+	if modified.IsZero() {
+		// notes never modified have no modification date
+		modified = note.DateAdded
+	}
+	return inRange(note.DateAdded, options.CreatedStartTime, options.CreatedEndTime) &&
+		inRange(modified, options.ModifiedStartTime, options.ModifiedEndTime)
+}
+
+// inRange indicates if a time is in [start, end[, zero bounds being ignored
+func inRange(t time.Time, start time.Time, end time.Time) bool {
+	if !start.IsZero() && t.Before(start) {
+		return false
+	}
+	if !end.IsZero() && !t.Before(end) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListNotes(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	basePath := filepath.Join(os.Getenv("HOME"), ".config", "vivaldi", "Default")
+	system.WriteFile(filepath.Join(basePath, "Notes"), []byte(`{
+  "checksum": "0123456789abcdef",
+  "children": [
+    {
+      "children": [
+        {
+          "children": [
+            {
+              "attachments": [{ "checksum": "abcd", "content": "image/png" }],
+              "content": "Compare the SQLite WAL modes",
+              "date_added": "13390300334000000",
+              "id": "4",
+              "subject": "WAL",
+              "type": "note",
+              "url": "https://sqlite.org/wal.html"
+            }
+          ],
+          "content": "",
+          "date_added": "13390300334000000",
+          "id": "3",
+          "subject": "Research",
+          "type": "folder"
+        }
+      ],
+      "content": "",
+      "date_added": "13390300334000000",
+      "id": "2",
+      "subject": "Work",
+      "type": "folder"
+    },
+    {
+      "content": "Buy milk",
+      "date_added": "13390386734000000",
+      "date_modified": "13390473134000000",
+      "id": "5",
+      "subject": "",
+      "type": "note"
+    },
+    {
+      "children": [
+        {
+          "content": "Deleted note",
+          "date_added": "13390300334000000",
+          "id": "7",
+          "subject": "",
+          "type": "note"
+        }
+      ],
+      "content": "",
+      "date_added": "13390300334000000",
+      "id": "6",
+      "subject": "Trash",
+      "type": "trash"
+    }
+  ]
+}`), 0644)
+
+	wal := api.Note{
+		Title:       "WAL",
+		Content:     "Compare the SQLite WAL modes",
+		URL:         "https://sqlite.org/wal.html",
+		Folder:      []string{"Work", "Research"},
+		Attachments: 1,
+		DateAdded:   time.Date(2025, 4, 28, 7, 52, 14, 0, time.UTC),
+	}
+	milk := api.Note{
+		Content:      "Buy milk",
+		Folder:       []string{},
+		DateAdded:    time.Date(2025, 4, 29, 7, 52, 14, 0, time.UTC),
+		DateModified: time.Date(2025, 4, 30, 7, 52, 14, 0, time.UTC),
+	}
+
+	for _, tt := range []struct {
+		name     string
+		options  api.ListNotesOptions
+		expected []api.Note
+	}{
+		{
+			name:     "all notes, except the trash",
+			expected: []api.Note{wal, milk},
+		},
+		{
+			name:     "folder and subfolders",
+			options:  api.ListNotesOptions{Folder: "work"},
+			expected: []api.Note{wal},
+		},
+		{
+			name:     "subfolder",
+			options:  api.ListNotesOptions{Folder: "Work/Research/"},
+			expected: []api.Note{wal},
+		},
+		{
+			name:     "folder prefix is not a folder",
+			options:  api.ListNotesOptions{Folder: "Wo"},
+			expected: []api.Note{},
+		},
+		{
+			name:     "text in URL",
+			options:  api.ListNotesOptions{Text: "SQLITE.ORG"},
+			expected: []api.Note{wal},
+		},
+		{
+			name: "created on a day",
+			options: api.ListNotesOptions{
+				CreatedStartTime: time.Date(2025, 4, 29, 0, 0, 0, 0, time.UTC),
+				CreatedEndTime:   time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC),
+			},
+			expected: []api.Note{milk},
+		},
+		{
+			name: "modified before a day, using the creation date of the notes never modified",
+			options: api.ListNotesOptions{
+				ModifiedEndTime: time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC),
+			},
+			expected: []api.Note{wal},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			notes, err := ListNotes(basePath, tt.options)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, notes); diff != "" {
+				t.Errorf("notes mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -10,16 +10,28 @@ import (
 	"github.com/feloy/browsers-mcp-server/pkg/api"
 )
 
-func SearchEngineQueries(profile string, options api.SearchEngineOptions) ([]api.SearchEngineQuery, error) {
-	log.Debug("searching engine queries", "profile", profile, "options", options)
+const searchEngineQueriesSQL = `SELECT 
+	visits.visit_time,
+	urls.url
+FROM urls
+INNER JOIN visits ON visits.url = urls.id
+WHERE 
+	urls.url like 'https://www.google.com/search%'
+	AND visits.visit_time >= ?
+	AND visits.visit_time < ?
+	ORDER BY visits.visit_time ASC
+LIMIT ?`
+
+func SearchEngineQueries(profilePath string, options api.SearchEngineOptions) ([]api.SearchEngineQuery, error) {
+	log.Debug("searching engine queries", "profilePath", profilePath, "options", options)
 
 	type queryResult struct {
 		VisitTime int64
 		URL       string
 	}
 
-	filename := filepath.Join(getUserDataDirecory(), profile, "History")
-	db, err := getDb(filename)
+	filename := filepath.Join(profilePath, "History")
+	db, err := getHistoryDb(filename)
 	if err != nil {
 		return nil, err
 	}
@@ -27,19 +39,9 @@ func SearchEngineQueries(profile string, options api.SearchEngineOptions) ([]api
 
 	startTime := toDbDate(options.StartTime)
 	endTime := toDbDate(options.EndTime)
-	rows, err := db.Query(`SELECT 
-	visits.visit_time,
-	urls.url
-FROM urls
-INNER JOIN visits ON visits.url = urls.id
-WHERE 
-	urls.url like 'https://www.google.com/search%'
-	AND visits.visit_time >= ?
-	AND visits.visit_time < ?
-	ORDER BY visits.visit_time ASC
-LIMIT ?`, startTime, endTime, options.Limit)
+	rows, err := db.Query(db.Queries.searchEngineQueries, startTime, endTime, options.Limit)
 	if err != nil {
-		return nil, err
+		return nil, db.Err(err)
 	}
 	defer rows.Close()
 
@@ -65,22 +67,7 @@ LIMIT ?`, startTime, endTime, options.Limit)
 	return searchEngineQueries, nil
 }
 
-func ListVisitedPagesFromSearchEngineQuery(profile string, options api.ListVisitedPagesFromSearchEngineQueryOptions) ([]api.VisitedPageFromSearchEngineQuery, error) {
-	type queryResult struct {
-		VisitTime int64
-		URL       string
-		Title     string
-	}
-	filename := filepath.Join(getUserDataDirecory(), profile, "History")
-	db, err := getDb(filename)
-	if err != nil {
-		return nil, err
-	}
-	defer db.Close()
-
-	startTime := toDbDate(options.StartTime)
-	endTime := toDbDate(options.EndTime)
-	rows, err := db.Query(`SELECT
+const visitedPagesFromSearchEngineQuerySQL = `SELECT
 visited.visit_time,
 visited_url.url,
 visited_url.title
@@ -93,10 +80,44 @@ WHERE
 	AND (? = '' OR urls.url like ? OR urls.url like ?)
   AND visits.visit_time >= ?
 	AND visits.visit_time < ?
-ORDER BY visits.visit_time ASC`, options.Query, "%q="+url.QueryEscape(options.Query)+"&%", "%q="+url.QueryEscape(options.Query), startTime, endTime)
+ORDER BY visits.visit_time ASC, visited.visit_time ASC`
+
+// visitedPagesFromSearchEngineQueryWithOpenerSQL also returns the pages opened in a new tab from the search results,
+// linked to the visit of the search results by the opener_visit column
+const visitedPagesFromSearchEngineQueryWithOpenerSQL = `SELECT
+visited.visit_time,
+visited_url.url,
+visited_url.title
+FROM urls
+INNER JOIN visits ON visits.url = urls.id
+INNER JOIN visits visited on visited.from_visit = visits.id OR visited.opener_visit = visits.id
+INNER JOIN urls visited_url on visited_url.id = visited.url
+WHERE 
+  urls.url like 'https://www.google.com/search%'
+	AND (? = '' OR urls.url like ? OR urls.url like ?)
+  AND visits.visit_time >= ?
+	AND visits.visit_time < ?
+ORDER BY visits.visit_time ASC, visited.visit_time ASC`
+
+func ListVisitedPagesFromSearchEngineQuery(profilePath string, options api.ListVisitedPagesFromSearchEngineQueryOptions) ([]api.VisitedPageFromSearchEngineQuery, error) {
+	type queryResult struct {
+		VisitTime int64
+		URL       string
+		Title     string
+	}
+	filename := filepath.Join(profilePath, "History")
+	db, err := getHistoryDb(filename)
 	if err != nil {
 		return nil, err
 	}
+	defer db.Close()
+
+	startTime := toDbDate(options.StartTime)
+	endTime := toDbDate(options.EndTime)
+	rows, err := db.Query(db.Queries.visitedPagesFromSearchEngineQuery, options.Query, "%q="+url.QueryEscape(options.Query)+"&%", "%q="+url.QueryEscape(options.Query), startTime, endTime)
+	if err != nil {
+		return nil, db.Err(err)
+	}
 	defer rows.Close()
 
 	var visitedPages []api.VisitedPageFromSearchEngineQuery
@@ -0,0 +1,143 @@
+package files
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+// historyDb is the History database of a Chrome profile
+var historyDb = globaltest.Fixture{
+	Path: filepath.Join("/profiles/Default", "History"),
+	Schema: []string{
+		`CREATE TABLE meta (key LONGVARCHAR NOT NULL UNIQUE PRIMARY KEY, value LONGVARCHAR)`,
+		`INSERT INTO meta (key, value) VALUES ('version', '70')`,
+		`CREATE TABLE urls (id INTEGER PRIMARY KEY AUTOINCREMENT, url LONGVARCHAR, title LONGVARCHAR, visit_count INTEGER DEFAULT 0 NOT NULL, typed_count INTEGER DEFAULT 0 NOT NULL, last_visit_time INTEGER NOT NULL, hidden INTEGER DEFAULT 0 NOT NULL)`,
+		`CREATE TABLE visits (id INTEGER PRIMARY KEY AUTOINCREMENT, url INTEGER NOT NULL, visit_time INTEGER NOT NULL, from_visit INTEGER, transition INTEGER DEFAULT 0 NOT NULL, segment_id INTEGER, visit_duration INTEGER DEFAULT 0 NOT NULL, opener_visit INTEGER)`,
+	},
+}
+
+// historyDbWithoutOpener is the History database of the versions read without the opener_visit column
+var historyDbWithoutOpener = globaltest.Fixture{
+	Path: historyDb.Path,
+	Schema: []string{
+		`CREATE TABLE meta (key LONGVARCHAR NOT NULL UNIQUE PRIMARY KEY, value LONGVARCHAR)`,
+		`INSERT INTO meta (key, value) VALUES ('version', '55')`,
+		`CREATE TABLE urls (id INTEGER PRIMARY KEY AUTOINCREMENT, url LONGVARCHAR, title LONGVARCHAR, visit_count INTEGER DEFAULT 0 NOT NULL, typed_count INTEGER DEFAULT 0 NOT NULL, last_visit_time INTEGER NOT NULL, hidden INTEGER DEFAULT 0 NOT NULL)`,
+		`CREATE TABLE visits (id INTEGER PRIMARY KEY AUTOINCREMENT, url INTEGER NOT NULL, visit_time INTEGER NOT NULL, from_visit INTEGER, transition INTEGER DEFAULT 0 NOT NULL, segment_id INTEGER)`,
+	},
+}
+
+func TestSearchEngineQueries(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	profilePath := historyDb.Write(t,
+		`INSERT INTO urls (id, url, title, last_visit_time) VALUES
+			(1, 'https://www.google.com/search?q=chromium+snss&sourceid=chrome', 'chromium snss - Google Search', 0),
+			(2, 'https://www.chromium.org/developers/', 'For Developers', 0),
+			(3, 'https://www.google.com/search?q=devtools', 'devtools - Google Search', 0),
+			(4, 'https://www.google.com/search?q=chromium+snss', 'chromium snss - Google Search', 0),
+			(5, 'https://developer.chrome.com/docs/devtools', 'DevTools', 0),
+			(6, 'https://chromium.googlesource.com/', 'Chromium Git repositories', 0)`,
+		// 2025-04-28T07:52:14Z, 2025-04-28T07:53:14Z, 2025-04-28T07:53:15Z, 2025-04-28T07:53:16Z, 2025-04-29T07:52:14Z, 2025-04-28T07:53:17Z
+		`INSERT INTO visits (id, url, visit_time, from_visit, opener_visit) VALUES
+			(1, 1, 13390300334000000, 0, NULL),
+			(2, 2, 13390300394000000, 1, NULL),
+			(3, 4, 13390300395000000, 0, NULL),
+			(4, 5, 13390300396000000, 3, NULL),
+			(5, 3, 13390386734000000, 0, NULL),
+			(6, 6, 13390300397000000, 0, 1)`,
+	)
+	startTime := globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T00:00:00Z"))
+	endTime := startTime.AddDate(0, 0, 1)
+	timeComparer := cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })
+
+	queries, err := SearchEngineQueries(profilePath, api.SearchEngineOptions{StartTime: startTime, EndTime: endTime, Limit: 10})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedQueries := []api.SearchEngineQuery{
+		{Query: "chromium snss", Date: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:52:14Z")), SearchEngine: "Google"},
+		{Query: "chromium snss", Date: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:53:15Z")), SearchEngine: "Google"},
+	}
+	if diff := cmp.Diff(expectedQueries, queries, timeComparer); diff != "" {
+		t.Errorf("Queries differ:\n%s", diff)
+	}
+
+	queries, err = SearchEngineQueries(profilePath, api.SearchEngineOptions{StartTime: startTime, EndTime: endTime, Limit: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if diff := cmp.Diff(expectedQueries[:1], queries, timeComparer); diff != "" {
+		t.Errorf("Limited queries differ:\n%s", diff)
+	}
+
+	pages, err := ListVisitedPagesFromSearchEngineQuery(profilePath, api.ListVisitedPagesFromSearchEngineQueryOptions{Query: "chromium snss", StartTime: startTime, EndTime: endTime})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedPages := []api.VisitedPageFromSearchEngineQuery{
+		{URL: "https://www.chromium.org/developers/", Title: "For Developers", Date: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:53:14Z")), SearchEngine: "Google"},
+		{URL: "https://chromium.googlesource.com/", Title: "Chromium Git repositories", Date: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:53:17Z")), SearchEngine: "Google"},
+		{URL: "https://developer.chrome.com/docs/devtools", Title: "DevTools", Date: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:53:16Z")), SearchEngine: "Google"},
+	}
+	if diff := cmp.Diff(expectedPages, pages, timeComparer); diff != "" {
+		t.Errorf("Visited pages differ:\n%s", diff)
+	}
+}
+
+func TestSearchEngineQueriesWithoutOpener(t *testing.T) {
+	startTime := globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T00:00:00Z"))
+	endTime := startTime.AddDate(0, 0, 1)
+	timeComparer := cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })
+
+	for _, version := range []string{"17", "55"} {
+		t.Run(version, func(t *testing.T) {
+			globaltest.MemFileSystem(t)
+			profilePath := historyDbWithoutOpener.Write(t,
+				`UPDATE meta SET value = '`+version+`' WHERE key = 'version'`,
+				`INSERT INTO urls (id, url, title, last_visit_time) VALUES
+					(1, 'https://www.google.com/search?q=chromium+snss&sourceid=chrome', 'chromium snss - Google Search', 0),
+					(2, 'https://www.chromium.org/developers/', 'For Developers', 0)`,
+				// 2025-04-28T07:52:14Z, 2025-04-28T07:53:14Z
+				`INSERT INTO visits (id, url, visit_time, from_visit) VALUES
+					(1, 1, 13390300334000000, 0),
+					(2, 2, 13390300394000000, 1)`,
+			)
+
+			queries, err := SearchEngineQueries(profilePath, api.SearchEngineOptions{StartTime: startTime, EndTime: endTime, Limit: 10})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			expectedQueries := []api.SearchEngineQuery{
+				{Query: "chromium snss", Date: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:52:14Z")), SearchEngine: "Google"},
+			}
+			if diff := cmp.Diff(expectedQueries, queries, timeComparer); diff != "" {
+				t.Errorf("Queries differ:\n%s", diff)
+			}
+
+			pages, err := ListVisitedPagesFromSearchEngineQuery(profilePath, api.ListVisitedPagesFromSearchEngineQueryOptions{Query: "chromium snss", StartTime: startTime, EndTime: endTime})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			expectedPages := []api.VisitedPageFromSearchEngineQuery{
+				{URL: "https://www.chromium.org/developers/", Title: "For Developers", Date: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:53:14Z")), SearchEngine: "Google"},
+			}
+			if diff := cmp.Diff(expectedPages, pages, timeComparer); diff != "" {
+				t.Errorf("Visited pages differ:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSearchEngineQueriesUnsupportedSchema(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	profilePath := historyDb.Write(t, `UPDATE meta SET value = '16' WHERE key = 'version'`)
+	_, err := SearchEngineQueries(profilePath, api.SearchEngineOptions{StartTime: time.Now().AddDate(0, 0, -1), EndTime: time.Now(), Limit: 10})
+	if err == nil || err.Error() != "unsupported schema version 16 for chrome History" {
+		t.Errorf("Expected unsupported schema version error, got %v", err)
+	}
+}
@@ -0,0 +1,399 @@
+package files
+
+import (
+	"cmp"
+	"fmt"
+	"io/fs"
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/chrome/files/fields"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	"github.com/spf13/afero"
+)
+
+// IDs of the commands of the session files, as defined in components/sessions/core/session_service_commands.cc
+const (
+	sessionCommandSetTabWindow                     = 0
+	sessionCommandSetTabIndexInWindow              = 2
+	sessionCommandTabNavigationPathPrunedFromBack  = 5
+	sessionCommandUpdateTabNavigation              = 6
+	sessionCommandSetSelectedNavigationIndex       = 7
+	sessionCommandSetSelectedTabInIndex            = 8
+	sessionCommandTabNavigationPathPrunedFromFront = 11
+	sessionCommandSetPinnedState                   = 12
+	sessionCommandTabClosed                        = 16
+	sessionCommandWindowClosed                     = 17
+	sessionCommandLastActiveTime                   = 21
+	sessionCommandTabNavigationPathPruned          = 24
+	sessionCommandSetTabGroup                      = 25
+	sessionCommandSetTabGroupMetadata2             = 27
+)
+
+// tabGroupColors are the colors of the tab groups, indexed by their value in the session files
+var tabGroupColors = []string{"grey", "blue", "red", "yellow", "green", "pink", "purple", "cyan", "orange"}
+
+// Session contains the windows and tabs of a Chromium session
+type Session struct {
+	Windows []SessionWindow
+}
+
+type SessionWindow struct {
+	ID   int32
+	Tabs []SessionTab
+}
+
+type SessionTab struct {
+	ID     int32
+	Pinned bool
+	Group  *SessionTabGroup
+	// LastActive is zero when the tab has not been activated since the browser started
+	LastActive time.Time
+	// Navigations is the back/forward history of the tab, Navigations[CurrentNavigation] being the current page
+	Navigations       []SessionNavigation
+	CurrentNavigation int
+}
+
+type SessionNavigation struct {
+	Title string
+	URL   string
+}
+
+type SessionTabGroup struct {
+	Title string
+	Color string
+}
+
+// ReadSession reads the session of a profile, from the most recent `Sessions/Session_*` file
+func ReadSession(profilePath string) (*Session, error) {
+	paths, err := afero.Glob(system.FileSystem, filepath.Join(profilePath, "Sessions", "Session_*"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, &fs.PathError{Op: "open", Path: filepath.Join(profilePath, "Sessions", "Session_*"), Err: fs.ErrNotExist}
+	}
+	// the files are suffixed with their creation time, in microseconds since 1601
+	path := slices.MaxFunc(paths, func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(a), len(b)), strings.Compare(a, b))
+	})
+	commands, err := ReadSNSS(path)
+	if err != nil {
+		return nil, err
+	}
+	session, err := replaySession(commands)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return session, nil
+}
+
+type idAndIndexPayload struct {
+	ID    int32
+	Index int32
+}
+
+type pinnedStatePayload struct {
+	TabID  int32
+	Pinned bool
+	_      [3]byte
+}
+
+type closedPayload struct {
+	ID int32
+	_  [4]byte
+	// CloseTime is in microseconds since 1601
+	CloseTime int64
+}
+
+type lastActiveTimePayload struct {
+	TabID int32
+	_     [4]byte
+	// LastActiveTime is in microseconds since 1601
+	LastActiveTime int64
+}
+
+type tabNavigationPathPrunedPayload struct {
+	TabID int32
+	Index int32
+	Count int32
+}
+
+type tabGroupToken struct {
+	High uint64
+	Low  uint64
+}
+
+type tabGroupPayload struct {
+	TabID    int32
+	_        [4]byte
+	Token    tabGroupToken
+	HasGroup bool
+	_        [7]byte
+}
+
+// sessionTabState is the state of a tab while replaying the commands
+type sessionTabState struct {
+	windowID      int32
+	index         int32
+	pinned        bool
+	group         *tabGroupToken
+	lastActive    time.Time
+	navigations   map[int32]SessionNavigation
+	selectedIndex int32
+}
+
+// replaySession rebuilds the windows and tabs of a session from the commands of its file
+func replaySession(commands []SNSSCommand) (*Session, error) {
+	var windowIDs []int32
+	closedWindows := map[int32]bool{}
+	tabs := map[int32]*sessionTabState{}
+	groups := map[tabGroupToken]SessionTabGroup{}
+
+	addWindow := func(id int32) {
+		if !slices.Contains(windowIDs, id) {
+			windowIDs = append(windowIDs, id)
+		}
+	}
+	getTab := func(id int32) *sessionTabState {
+		tab, ok := tabs[id]
+		if !ok {
+			tab = &sessionTabState{navigations: map[int32]SessionNavigation{}, selectedIndex: -1}
+			tabs[id] = tab
+		}
+		return tab
+	}
+
+	for _, command := range commands {
+		switch command.ID {
+		case sessionCommandSetTabWindow:
+			var payload struct{ WindowID, TabID int32 }
+			if err := command.readPayload(&payload); err != nil {
+				return nil, err
+			}
+			addWindow(payload.WindowID)
+			getTab(payload.TabID).windowID = payload.WindowID
+
+		case sessionCommandSetTabIndexInWindow:
+			var payload idAndIndexPayload
+			if err := command.readPayload(&payload); err != nil {
+				return nil, err
+			}
+			getTab(payload.ID).index = payload.Index
+
+		case sessionCommandSetSelectedTabInIndex:
+			var payload idAndIndexPayload
+			if err := command.readPayload(&payload); err != nil {
+				return nil, err
+			}
+			addWindow(payload.ID)
+
+		case sessionCommandUpdateTabNavigation:
+			p := command.pickle()
+			tabID := p.readInt32()
+			index := p.readInt32()
+			url := p.readString()
+			title := p.readString16()
+			if p.err != nil {
+				return nil, fmt.Errorf("invalid navigation: %w", p.err)
+			}
+			getTab(tabID).navigations[index] = SessionNavigation{Title: title, URL: url}
+
+		case sessionCommandSetSelectedNavigationIndex:
+			var payload idAndIndexPayload
+			if err := command.readPayload(&payload); err != nil {
+				return nil, err
+			}
+			getTab(payload.ID).selectedIndex = payload.Index
+
+		case sessionCommandTabNavigationPathPrunedFromBack:
+			var payload idAndIndexPayload
+			if err := command.readPayload(&payload); err != nil {
+				return nil, err
+			}
+			getTab(payload.ID).prune(int64(payload.Index), math.MaxInt32)
+
+		case sessionCommandTabNavigationPathPrunedFromFront:
+			var payload idAndIndexPayload
+			if err := command.readPayload(&payload); err != nil {
+				return nil, err
+			}
+			getTab(payload.ID).prune(0, int64(payload.Index))
+
+		case sessionCommandTabNavigationPathPruned:
+			var payload tabNavigationPathPrunedPayload
+			if err := command.readPayload(&payload); err != nil {
+				return nil, err
+			}
+			getTab(payload.TabID).prune(int64(payload.Index), int64(payload.Count))
+
+		case sessionCommandSetPinnedState:
+			var payload pinnedStatePayload
+			if err := command.readPayload(&payload); err != nil {
+				return nil, err
+			}
+			getTab(payload.TabID).pinned = payload.Pinned
+
+		case sessionCommandTabClosed:
+			var payload closedPayload
+			if err := command.readPayload(&payload); err != nil {
+				return nil, err
+			}
+			delete(tabs, payload.ID)
+
+		case sessionCommandWindowClosed:
+			var payload closedPayload
+			if err := command.readPayload(&payload); err != nil {
+				return nil, err
+			}
+			closedWindows[payload.ID] = true
+
+		case sessionCommandLastActiveTime:
+			var payload lastActiveTimePayload
+			if err := command.readPayload(&payload); err != nil {
+				return nil, err
+			}
+			getTab(payload.TabID).lastActive = fromSessionDate(payload.LastActiveTime)
+
+		case sessionCommandSetTabGroup:
+			var payload tabGroupPayload
+			if err := command.readPayload(&payload); err != nil {
+				return nil, err
+			}
+			tab := getTab(payload.TabID)
+			tab.group = nil
+			if payload.HasGroup {
+				tab.group = &payload.Token
+			}
+
+		case sessionCommandSetTabGroupMetadata2:
+			p := command.pickle()
+			token := tabGroupToken{High: p.readUint64(), Low: p.readUint64()}
+			title := p.readString16()
+			color := p.readUint32()
+			if p.err != nil {
+				return nil, fmt.Errorf("invalid tab group: %w", p.err)
+			}
+			group := SessionTabGroup{Title: title}
+			if int(color) < len(tabGroupColors) {
+				group.Color = tabGroupColors[color]
+			}
+			groups[token] = group
+		}
+	}
+
+	session := &Session{}
+	for _, windowID := range windowIDs {
+		if closedWindows[windowID] {
+			continue
+		}
+		var windowTabs []int32
+		for id, tab := range tabs {
+			if tab.windowID == windowID && len(tab.navigations) > 0 {
+				windowTabs = append(windowTabs, id)
+			}
+		}
+		if len(windowTabs) == 0 {
+			continue
+		}
+		slices.SortFunc(windowTabs, func(a, b int32) int {
+			return cmp.Or(cmp.Compare(tabs[a].index, tabs[b].index), cmp.Compare(a, b))
+		})
+		window := SessionWindow{ID: windowID}
+		for _, id := range windowTabs {
+			window.Tabs = append(window.Tabs, tabs[id].toSessionTab(id, groups))
+		}
+		session.Windows = append(session.Windows, window)
+	}
+	return session, nil
+}
+
+// prune removes count navigations starting at index, and shifts the following ones
+func (o *sessionTabState) prune(index int64, count int64) {
+	if count <= 0 {
+		return
+	}
+	navigations := map[int32]SessionNavigation{}
+	for i, navigation := range o.navigations {
+		switch {
+		case int64(i) < index:
+			navigations[i] = navigation
+		case int64(i) >= index+count:
+			navigations[int32(int64(i)-count)] = navigation
+		}
+	}
+	o.navigations = navigations
+	switch selected := int64(o.selectedIndex); {
+	case selected >= index+count:
+		o.selectedIndex = int32(selected - count)
+	case selected >= index:
+		o.selectedIndex = int32(index - 1)
+	}
+}
+
+func (o *sessionTabState) toSessionTab(id int32, groups map[tabGroupToken]SessionTabGroup) SessionTab {
+	tab := SessionTab{
+		ID:         id,
+		Pinned:     o.pinned,
+		LastActive: o.lastActive,
+	}
+	if o.group != nil {
+		group := groups[*o.group]
+		tab.Group = &group
+	}
+	indexes := make([]int32, 0, len(o.navigations))
+	for index := range o.navigations {
+		indexes = append(indexes, index)
+	}
+	slices.Sort(indexes)
+	// the last navigation is the current one when none is selected
+	tab.CurrentNavigation = len(indexes) - 1
+	for i, index := range indexes {
+		tab.Navigations = append(tab.Navigations, o.navigations[index])
+		if index == o.selectedIndex {
+			tab.CurrentNavigation = i
+		}
+	}
+	return tab
+}
+
+// ListOpenTabs returns the current page of the tabs of the session, with their back/forward history
+func (o *Session) ListOpenTabs() []api.Tab {
+	result := []api.Tab{}
+	for i, window := range o.Windows {
+		for _, tab := range window.Tabs {
+			current := tab.Navigations[tab.CurrentNavigation]
+			apiTab := api.Tab{
+				Title:        current.Title,
+				URL:          current.URL,
+				Window:       i + 1,
+				Pinned:       tab.Pinned,
+				LastAccessed: tab.LastActive,
+			}
+			if tab.Group != nil {
+				apiTab.Group = tab.Group.Title
+				apiTab.GroupColor = tab.Group.Color
+			}
+			for _, navigation := range tab.Navigations[:tab.CurrentNavigation] {
+				apiTab.Back = append(apiTab.Back, api.TabEntry{Title: navigation.Title, URL: navigation.URL})
+			}
+			for _, navigation := range tab.Navigations[tab.CurrentNavigation+1:] {
+				apiTab.Forward = append(apiTab.Forward, api.TabEntry{Title: navigation.Title, URL: navigation.URL})
+			}
+			result = append(result, apiTab)
+		}
+	}
+	return result
+}
+
+// fromSessionDate converts the dates of the session, in microseconds since 1601
+func fromSessionDate(date int64) time.Time {
+	if date <= 0 {
+		return time.Time{}
+	}
+	return fields.FromInt(date, 0, fields.Micro, fields.Windows)
+}
@@ -0,0 +1,78 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadSession(t *testing.T) {
+	// testdata/Sessions/Session_13370000300000000 contains 3 windows:
+	// - the first one with a pinned tab, a tab whose history was pruned, a tab in a group and a closed tab,
+	// - the second one closed,
+	// - the third one with a tab in an unnamed group, and a tab whose forward history was pruned.
+	fixture, err := os.ReadFile(filepath.Join("testdata", "Sessions", "Session_13370000300000000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	globaltest.MemFileSystem(t)
+	profilePath := "/profiles/Default"
+	system.WriteFile(filepath.Join(profilePath, "Sessions", "Session_13370000300000000"), fixture, 0644)
+	// older session, not read
+	system.WriteFile(filepath.Join(profilePath, "Sessions", "Session_13369999999999999"), []byte("SNSS\x03\x00\x00\x00"), 0644)
+	system.WriteFile(filepath.Join(profilePath, "Sessions", "Tabs_13370000300000000"), []byte("SNSS\x03\x00\x00\x00"), 0644)
+
+	session, err := ReadSession(profilePath)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []api.Tab{
+		{
+			Title:        "The Go Programming Language",
+			URL:          "https://go.dev/",
+			Window:       1,
+			Pinned:       true,
+			LastAccessed: time.Date(2024, 9, 5, 8, 53, 20, 0, time.UTC),
+			Back:         []api.TabEntry{{Title: "New Tab", URL: "chrome://newtab/"}},
+			Forward:      []api.TabEntry{{Title: "Go Packages", URL: "https://pkg.go.dev/"}},
+		},
+		{
+			Title:  "D",
+			URL:    "https://d.example.com/",
+			Window: 1,
+			Back:   []api.TabEntry{{Title: "C", URL: "https://c.example.com/"}},
+		},
+		{
+			Title:      "Chromium — Projets",
+			URL:        "https://www.chromium.org/",
+			Window:     1,
+			Group:      "Research",
+			GroupColor: "blue",
+		},
+		{
+			Title:      "Example Domain",
+			URL:        "https://example.com/",
+			Window:     2,
+			GroupColor: "orange",
+		},
+		{
+			Title:   "One",
+			URL:     "https://one.example.com/",
+			Window:  2,
+			Forward: []api.TabEntry{{Title: "Three", URL: "https://three.example.com/"}},
+		},
+	}
+	if diff := cmp.Diff(expected, session.ListOpenTabs()); diff != "" {
+		t.Errorf("tabs mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err = ReadSession("/profiles/Profile 1"); err == nil {
+		t.Error("expected an error for a profile without session")
+	}
+}
@@ -0,0 +1,164 @@
+package files
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"unicode/utf16"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+// SNSS files are the binary files in which Chromium persists its sessions (`Sessions/Session_*`)
+// and its recently closed tabs (`Sessions/Tabs_*`). They contain a header, followed by a list of commands
+// replayed when the session is restored.
+const snssSignature = "SNSS"
+
+const (
+	snssVersion           = 1
+	snssVersionWithMarker = 3
+	// snssMarkerCommand is the command ending the initial state of the files with marker
+	snssMarkerCommand = 255
+)
+
+// SNSSCommand is a command of an SNSS file, whose payload format depends on its ID
+type SNSSCommand struct {
+	ID      uint8
+	Payload []byte
+}
+
+// ReadSNSS returns the commands of an SNSS file
+func ReadSNSS(path string) ([]SNSSCommand, error) {
+	data, err := system.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	commands, err := DecodeSNSS(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return commands, nil
+}
+
+// DecodeSNSS returns the commands of the content of an SNSS file
+func DecodeSNSS(data []byte) ([]SNSSCommand, error) {
+	if len(data) < 8 || string(data[:4]) != snssSignature {
+		return nil, fmt.Errorf("not an SNSS file")
+	}
+	// versions 2 and 4 are encrypted
+	switch version := binary.LittleEndian.Uint32(data[4:8]); version {
+	case snssVersion, snssVersionWithMarker:
+	default:
+		return nil, fmt.Errorf("unsupported SNSS version %d", version)
+	}
+	data = data[8:]
+	var commands []SNSSCommand
+	for len(data) > 0 {
+		if len(data) < 2 {
+			return nil, fmt.Errorf("truncated SNSS command")
+		}
+		// size of the command, including its ID
+		size := int(binary.LittleEndian.Uint16(data))
+		data = data[2:]
+		if size == 0 {
+			continue
+		}
+		if len(data) < size {
+			// the last command is incomplete when the browser is writing the file
+			break
+		}
+		if data[0] != snssMarkerCommand {
+			commands = append(commands, SNSSCommand{ID: data[0], Payload: data[1:size]})
+		}
+		data = data[size:]
+	}
+	return commands, nil
+}
+
+// readPayload decodes the fixed-size payload of a command into a struct, whose blank fields are the padding of the C++ struct
+func (o SNSSCommand) readPayload(payload any) error {
+	if err := binary.Read(bytes.NewReader(o.Payload), binary.LittleEndian, payload); err != nil {
+		return fmt.Errorf("invalid payload for SNSS command %d: %w", o.ID, err)
+	}
+	return nil
+}
+
+// pickle reads the variable-size payloads of the commands, serialized as a Chromium `base::Pickle`:
+// a header containing the size of the data, followed by values aligned on 32 bits.
+type pickle struct {
+	data []byte
+	err  error
+}
+
+func (o SNSSCommand) pickle() *pickle {
+	p := &pickle{}
+	if len(o.Payload) < 4 {
+		p.err = fmt.Errorf("invalid pickle for SNSS command %d", o.ID)
+		return p
+	}
+	size := binary.LittleEndian.Uint32(o.Payload)
+	if uint64(size) > uint64(len(o.Payload)-4) {
+		p.err = fmt.Errorf("invalid pickle for SNSS command %d", o.ID)
+		return p
+	}
+	p.data = o.Payload[4 : 4+size]
+	return p
+}
+
+// read returns the next n bytes, and skips their alignment
+func (p *pickle) read(n int) []byte {
+	if p.err != nil {
+		return nil
+	}
+	aligned := (n + 3) &^ 3
+	if n < 0 || aligned > len(p.data) {
+		p.err = fmt.Errorf("truncated pickle")
+		return nil
+	}
+	result := p.data[:n]
+	p.data = p.data[aligned:]
+	return result
+}
+
+func (p *pickle) readInt32() int32 {
+	data := p.read(4)
+	if data == nil {
+		return 0
+	}
+	return int32(binary.LittleEndian.Uint32(data))
+}
+
+func (p *pickle) readUint32() uint32 {
+	return uint32(p.readInt32())
+}
+
+func (p *pickle) readUint64() uint64 {
+	data := p.read(8)
+	if data == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(data)
+}
+
+func (p *pickle) readString() string {
+	length := p.readInt32()
+	return string(p.read(int(length)))
+}
+
+func (p *pickle) readString16() string {
+	length := p.readInt32()
+	if length < 0 || length > math.MaxInt32/2 {
+		p.err = fmt.Errorf("invalid string length %d", length)
+		return ""
+	}
+	data := p.read(int(length) * 2)
+	if data == nil {
+		return ""
+	}
+	chars := make([]uint16, length)
+	for i := range chars {
+		chars[i] = binary.LittleEndian.Uint16(data[2*i:])
+	}
+	return string(utf16.Decode(chars))
+}
@@ -0,0 +1,86 @@
+package files
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecodeSNSS(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          []byte
+		expected      []SNSSCommand
+		expectedError string
+	}{
+		{
+			name: "commands",
+			data: []byte("SNSS\x01\x00\x00\x00" + "\x05\x00\x07\x0a\x00\x00\x00" + "\x00\x00" + "\x01\x00\x10"),
+			expected: []SNSSCommand{
+				{ID: 7, Payload: []byte{10, 0, 0, 0}},
+				{ID: 16, Payload: []byte{}},
+			},
+		},
+		{
+			name: "marker and incomplete command are skipped",
+			data: []byte("SNSS\x03\x00\x00\x00" + "\x01\x00\xff" + "\x03\x00\x08\x01\x02" + "\x10\x00\x06\x01"),
+			expected: []SNSSCommand{
+				{ID: 8, Payload: []byte{1, 2}},
+			},
+		},
+		{
+			name:          "not an SNSS file",
+			data:          []byte(`{"roots": {}}`),
+			expectedError: "not an SNSS file",
+		},
+		{
+			name:          "encrypted file",
+			data:          []byte("SNSS\x02\x00\x00\x00"),
+			expectedError: "unsupported SNSS version 2",
+		},
+		{
+			name:          "truncated command",
+			data:          []byte("SNSS\x01\x00\x00\x00\x05"),
+			expectedError: "truncated SNSS command",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, err := DecodeSNSS(tt.data)
+			if tt.expectedError != "" {
+				if err == nil || err.Error() != tt.expectedError {
+					t.Errorf("expected error %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, commands); diff != "" {
+				t.Errorf("commands mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPickle(t *testing.T) {
+	command := SNSSCommand{Payload: []byte("\x14\x00\x00\x00" + "\x2a\x00\x00\x00" + "\x02\x00\x00\x00ab\x00\x00" + "\x02\x00\x00\x00\xe9\x00\x74\x00" + "\x05\x00\x00\x00")}
+	p := command.pickle()
+	if n := p.readInt32(); n != 42 {
+		t.Errorf("expected 42, got %d", n)
+	}
+	if s := p.readString(); s != "ab" {
+		t.Errorf("expected %q, got %q", "ab", s)
+	}
+	if s := p.readString16(); s != "ét" {
+		t.Errorf("expected %q, got %q", "ét", s)
+	}
+	if p.err != nil {
+		t.Fatalf("expected no error, got %v", p.err)
+	}
+	// the last value is outside of the size of the pickle
+	p.readInt32()
+	if p.err == nil {
+		t.Error("expected an error reading after the end of the pickle")
+	}
+}
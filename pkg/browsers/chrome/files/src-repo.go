@@ -7,28 +7,7 @@ import (
 	"github.com/feloy/browsers-mcp-server/pkg/api"
 )
 
-func ListVisitedPagesFromSourceRepos(profile string, options api.ListVisitedPagesFromSourceReposOptions) ([]api.VisitedPageFromSourceRepos, error) {
-	log.Debug("source repository visits", "profile", profile, "options", options)
-
-	type queryResult struct {
-		Times        int
-		URL          string
-		Organization string
-		Repository   string
-		Pagetype     string
-		Name         string
-	}
-
-	filename := filepath.Join(getUserDataDirecory(), profile, "History")
-	db, err := getDb(filename)
-	if err != nil {
-		return nil, err
-	}
-	defer db.Close()
-
-	startTime := toDbDate(options.StartTime)
-	endTime := toDbDate(options.EndTime)
-	rows, err := db.Query(`with recursive 
+const visitedPagesFromSourceReposSQL = `with recursive 
   cte0 (title, pathAndQuery) as (
     SELECT 
       urls.title AS title,
@@ -110,10 +89,33 @@ select count(*) as c, url, organization, repository, pagetype, name from cte6
 where (? = '' OR ? = pagetype) AND pagetype != 'other details'
 group by url, organization, repository, pagetype, name
 order by c desc;
-`, startTime, endTime, options.Type, options.Type)
+`
+
+func ListVisitedPagesFromSourceRepos(profilePath string, options api.ListVisitedPagesFromSourceReposOptions) ([]api.VisitedPageFromSourceRepos, error) {
+	log.Debug("source repository visits", "profilePath", profilePath, "options", options)
+
+	type queryResult struct {
+		Times        int
+		URL          string
+		Organization string
+		Repository   string
+		Pagetype     string
+		Name         string
+	}
+
+	filename := filepath.Join(profilePath, "History")
+	db, err := getHistoryDb(filename)
 	if err != nil {
 		return nil, err
 	}
+	defer db.Close()
+
+	startTime := toDbDate(options.StartTime)
+	endTime := toDbDate(options.EndTime)
+	rows, err := db.Query(db.Queries.visitedPagesFromSourceRepos, startTime, endTime, options.Type, options.Type)
+	if err != nil {
+		return nil, db.Err(err)
+	}
 	defer rows.Close()
 
 	var visitedPages []api.VisitedPageFromSourceRepos
@@ -0,0 +1,61 @@
+package files
+
+import (
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListVisitedPagesFromSourceRepos(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	profilePath := historyDb.Write(t,
+		`INSERT INTO urls (id, url, title, last_visit_time) VALUES
+			(1, 'https://github.com/chromium/chromium', 'chromium/chromium', 0),
+			(2, 'https://github.com/chromium/chromium/pull/12?tab=files#diff', 'Pull request 12', 0),
+			(3, 'https://github.com/search?q=snss', 'Search', 0),
+			(4, 'https://github.com/chromium/chromium/issues', 'Issues', 0)`,
+		`INSERT INTO visits (id, url, visit_time) VALUES
+			(1, 1, 13390300334000000),
+			(2, 1, 13390300394000000),
+			(3, 2, 13390300395000000),
+			(4, 3, 13390300396000000),
+			(5, 4, 13390386734000000)`,
+	)
+	startTime := globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T00:00:00Z"))
+	endTime := startTime.AddDate(0, 0, 1)
+
+	number := "12"
+	for _, tt := range []struct {
+		name     string
+		pageType api.SourceRepoPageType
+		expected []api.VisitedPageFromSourceRepos
+	}{
+		{
+			name: "all types",
+			expected: []api.VisitedPageFromSourceRepos{
+				{Times: 2, Provider: "github", URL: "https://github.com/chromium/chromium", Organization: "chromium", Repository: "chromium", Type: api.SourceRepoPageTypeRepositoryHome},
+				{Times: 1, Provider: "github", URL: "https://github.com/chromium/chromium/pull/12", Organization: "chromium", Repository: "chromium", Type: api.SourceRepoPageTypePullRequest, Number: &number},
+			},
+		},
+		{
+			name:     "pull requests only",
+			pageType: api.SourceRepoPageTypePullRequest,
+			expected: []api.VisitedPageFromSourceRepos{
+				{Times: 1, Provider: "github", URL: "https://github.com/chromium/chromium/pull/12", Organization: "chromium", Repository: "chromium", Type: api.SourceRepoPageTypePullRequest, Number: &number},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			visits, err := ListVisitedPagesFromSourceRepos(profilePath, api.ListVisitedPagesFromSourceReposOptions{Type: tt.pageType, StartTime: startTime, EndTime: endTime})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, visits); diff != "" {
+				t.Errorf("Visits differ:\n%s", diff)
+			}
+		})
+	}
+}
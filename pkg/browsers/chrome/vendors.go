@@ -0,0 +1,156 @@
+package chrome
+
+import (
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+const (
+	installFlatpak = "flatpak"
+	installSnap    = "snap"
+)
+
+// name of the single profile of browsers storing the profile files in the user data directory
+const defaultProfile = "Default"
+
+// Vendor describes where a browser of the Chromium family stores its user data on each platform
+type Vendor struct {
+	Name string
+	// Darwin is the user data directory, relative to $HOME/Library/Application Support
+	Darwin []string
+	// Windows is the user data directory, relative to %LOCALAPPDATA%, or to %APPDATA% if WindowsRoaming is set
+	Windows        []string
+	WindowsRoaming bool
+	// Linux is the user data directory, relative to $HOME/.config
+	Linux []string
+	// Flatpak is the user data directory of the Flatpak install on Linux, relative to $HOME/.var/app
+	Flatpak []string
+	// Snap is the user data directory of the Snap install on Linux, relative to $HOME/snap
+	Snap []string
+	// ProfileInRoot indicates that the browser stores the files of its single profile
+	// directly in the user data directory
+	ProfileInRoot bool
+	// Notes indicates that the browser stores the notes of the user in the `Notes` file of the profiles
+	Notes bool
+	// Collections indicates that the browser stores the collections of the user in the `Collections/collectionsSQLite` database of the profiles
+	Collections bool
+}
+
+var Vendors = []Vendor{
+	{
+		Name:    "chrome",
+		Darwin:  []string{"Google", "Chrome"},
+		Windows: []string{"Google", "Chrome", "User Data"},
+		Linux:   []string{"google-chrome"},
+		Flatpak: []string{"com.google.Chrome", "config", "google-chrome"},
+	},
+	{
+		Name:    "chrome-beta",
+		Darwin:  []string{"Google", "Chrome Beta"},
+		Windows: []string{"Google", "Chrome Beta", "User Data"},
+		Linux:   []string{"google-chrome-beta"},
+	},
+	{
+		Name:    "chrome-dev",
+		Darwin:  []string{"Google", "Chrome Dev"},
+		Windows: []string{"Google", "Chrome Dev", "User Data"},
+		Linux:   []string{"google-chrome-unstable"},
+		Flatpak: []string{"com.google.ChromeDev", "config", "google-chrome-unstable"},
+	},
+	{
+		Name:    "chrome-canary",
+		Darwin:  []string{"Google", "Chrome Canary"},
+		Windows: []string{"Google", "Chrome SxS", "User Data"},
+		Linux:   []string{"google-chrome-canary"},
+	},
+	{
+		Name:    "chromium",
+		Darwin:  []string{"Chromium"},
+		Windows: []string{"Chromium", "User Data"},
+		Linux:   []string{"chromium"},
+		Flatpak: []string{"org.chromium.Chromium", "config", "chromium"},
+		Snap:    []string{"chromium", "common", "chromium"},
+	},
+	{
+		Name:    "brave",
+		Darwin:  []string{"BraveSoftware", "Brave-Browser"},
+		Windows: []string{"BraveSoftware", "Brave-Browser", "User Data"},
+		Linux:   []string{"BraveSoftware", "Brave-Browser"},
+		Flatpak: []string{"com.brave.Browser", "config", "BraveSoftware", "Brave-Browser"},
+		Snap:    []string{"brave", "current", ".config", "BraveSoftware", "Brave-Browser"},
+	},
+	{
+		Name:        "edge",
+		Darwin:      []string{"Microsoft Edge"},
+		Windows:     []string{"Microsoft", "Edge", "User Data"},
+		Linux:       []string{"microsoft-edge"},
+		Flatpak:     []string{"com.microsoft.Edge", "config", "microsoft-edge"},
+		Collections: true,
+	},
+	{
+		Name:    "vivaldi",
+		Darwin:  []string{"Vivaldi"},
+		Windows: []string{"Vivaldi", "User Data"},
+		Linux:   []string{"vivaldi"},
+		Flatpak: []string{"com.vivaldi.Vivaldi", "config", "vivaldi"},
+		Notes:   true,
+	},
+	{
+		Name:           "opera",
+		Darwin:         []string{"com.operasoftware.Opera"},
+		Windows:        []string{"Opera Software", "Opera Stable"},
+		WindowsRoaming: true,
+		Linux:          []string{"opera"},
+		Flatpak:        []string{"com.opera.Opera", "config", "opera"},
+		Snap:           []string{"opera", "current", ".config", "opera"},
+		ProfileInRoot:  true,
+	},
+}
+
+// userDataDirectory returns the user data directory of the native install of the browser on a platform,
+// or an empty string when the browser is not available on the platform
+func (o Vendor) userDataDirectory(platform system.Platform) string {
+	if platform.Os == "darwin" && len(o.Darwin) > 0 {
+		return platform.HomePath(append([]string{"Library", "Application Support"}, o.Darwin...)...)
+	}
+	if platform.Os == "windows" && len(o.Windows) > 0 {
+		if o.WindowsRoaming {
+			return platform.AppDataPath(o.Windows...)
+		}
+		return platform.LocalAppDataPath(o.Windows...)
+	}
+	if platform.Os == "linux" && len(o.Linux) > 0 {
+		return platform.HomePath(append([]string{".config"}, o.Linux...)...)
+	}
+	return ""
+}
+
+// install is a location where a browser stores its user data
+type install struct {
+	// name is empty for the native install
+	name              string
+	userDataDirectory string
+}
+
+// installs returns all the known locations of the user data of the browser on a platform
+func (o Vendor) installs(platform system.Platform) []install {
+	var result []install
+	if userDataDirectory := o.userDataDirectory(platform); userDataDirectory != "" {
+		result = append(result, install{userDataDirectory: userDataDirectory})
+	}
+	if platform.Os != "linux" {
+		return result
+	}
+	if len(o.Flatpak) > 0 {
+		result = append(result, install{
+			name:              installFlatpak,
+			userDataDirectory: platform.HomePath(append([]string{".var", "app"}, o.Flatpak...)...),
+		})
+	}
+	if len(o.Snap) > 0 {
+		result = append(result, install{
+			name:              installSnap,
+			userDataDirectory: platform.HomePath(append([]string{"snap"}, o.Snap...)...),
+		})
+	}
+	return result
+}
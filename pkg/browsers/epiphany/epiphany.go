@@ -0,0 +1,143 @@
+package epiphany
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/epiphany/files"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+// name of the single profile of an install
+const defaultProfile = "Default"
+
+const installFlatpak = "flatpak"
+
+var instance api.Browser = &Epiphany{}
+
+// Epiphany is a provider for GNOME Web
+type Epiphany struct{}
+
+func (o *Epiphany) Name() string {
+	return "epiphany"
+}
+
+func (o *Epiphany) IsAvailable() (bool, error) {
+	_, err := o.Profiles()
+	return err == nil, err
+}
+
+func (o *Epiphany) Bookmarks(profileName string) ([]api.BookMark, error) {
+	profilePath, err := o.profilePath(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.ListBookmarks(profilePath)
+}
+
+func (o *Epiphany) SearchEngineQueries(profileName string, options api.SearchEngineOptions) ([]api.SearchEngineQuery, error) {
+	profilePath, err := o.profilePath(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.SearchEngineQueries(profilePath, options)
+}
+
+func (o *Epiphany) ListVisitedPagesFromSearchEngineQuery(profileName string, options api.ListVisitedPagesFromSearchEngineQueryOptions) ([]api.VisitedPageFromSearchEngineQuery, error) {
+	profilePath, err := o.profilePath(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.ListVisitedPagesFromSearchEngineQuery(profilePath, options)
+}
+
+func (o *Epiphany) ListVisitedPagesFromSourceRepos(profileName string, options api.ListVisitedPagesFromSourceReposOptions) ([]api.VisitedPageFromSourceRepos, error) {
+	profilePath, err := o.profilePath(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.ListVisitedPagesFromSourceRepos(profilePath, options)
+}
+
+// install is a location where Epiphany stores its profile
+type install struct {
+	// name is empty for the native install
+	name string
+	path string
+}
+
+func installs() []install {
+	if dirs := system.ConfiguredDataDirectories(instance.Name()); len(dirs) > 0 {
+		var result []install
+		for _, dir := range dirs {
+			result = append(result, install{name: dir.Name, path: dir.Path})
+		}
+		return result
+	}
+	if system.Os != "linux" {
+		return nil
+	}
+	return []install{
+		{path: system.HomePath(".local", "share", "epiphany")},
+		{name: installFlatpak, path: system.HomePath(".var", "app", "org.gnome.Epiphany", "data", "epiphany")},
+	}
+}
+
+// Profiles returns the profiles of all the installs of the browser.
+// The ID of the profile of the Flatpak install is suffixed with the name of the install.
+func (o *Epiphany) Profiles() ([]api.Profile, error) {
+	var result []api.Profile
+	lastErr := fmt.Errorf("browser %q is not supported on %s", o.Name(), system.Os)
+	for _, install := range installs() {
+		_, err := system.FileSystem.Stat(filepath.Join(install.path, "ephy-history.db"))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				log.Debug("browser install not found", "browser", o.Name(), "install", install.name, "path", install.path)
+			} else {
+				log.Warn("unable to read browser install", "browser", o.Name(), "install", install.name, "path", install.path, "error", err)
+			}
+			lastErr = err
+			continue
+		}
+		p := api.Profile{
+			ID:      defaultProfile,
+			Name:    defaultProfile,
+			Path:    install.path,
+			Default: install.name == "",
+			Install: install.name,
+		}
+		history := filepath.Join(install.path, "ephy-history.db")
+		p.LastUsed = system.LastModified(history, history+"-wal")
+		if install.name != "" {
+			p.ID = fmt.Sprintf("%s (%s)", defaultProfile, install.name)
+		}
+		result = append(result, p)
+	}
+	if len(result) == 0 {
+		return nil, lastErr
+	}
+	return result, nil
+}
+
+// profilePath returns the directory containing the files of the profile
+func (o *Epiphany) profilePath(profileName string) (string, error) {
+	profiles, err := o.Profiles()
+	if err != nil {
+		return "", err
+	}
+	for _, profile := range profiles {
+		if profile.ID == profileName {
+			return profile.Path, nil
+		}
+	}
+	return "", fmt.Errorf("profile %s not found", profileName)
+}
+
+func init() {
+	browsers.Register(instance)
+}
@@ -0,0 +1,45 @@
+package epiphany
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/test"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProfiles(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	home := os.Getenv("HOME")
+
+	epiphany := &Epiphany{}
+	if available, _ := epiphany.IsAvailable(); available {
+		t.Errorf("expected epiphany not to be available")
+	}
+
+	system.WriteFile(filepath.Join(home, ".local", "share", "epiphany", "ephy-history.db"), []byte{}, 0644)
+	system.WriteFile(filepath.Join(home, ".var", "app", "org.gnome.Epiphany", "data", "epiphany", "ephy-history.db"), []byte{}, 0644)
+	profiles, err := epiphany.Profiles()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := []string{"Default", "Default (flatpak)"}; !cmp.Equal(expected, test.ProfileIDs(profiles)) {
+		t.Errorf("expected %v, got %v", expected, test.ProfileIDs(profiles))
+	}
+	path, err := epiphany.profilePath("Default (flatpak)")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := filepath.Join(home, ".var", "app", "org.gnome.Epiphany", "data", "epiphany"); path != expected {
+		t.Errorf("expected %s, got %s", expected, path)
+	}
+
+	system.Os = "darwin"
+	if available, _ := epiphany.IsAvailable(); available {
+		t.Errorf("expected epiphany not to be available on darwin")
+	}
+}
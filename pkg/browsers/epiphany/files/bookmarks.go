@@ -0,0 +1,70 @@
+package files
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+// bookmarkType is the GVariant type of the bookmarks stored in bookmarks.gvdb:
+// time added, title, id, server time modified, uploaded, tags
+const bookmarkType = "(xssdbas)"
+
+// ListBookmarks returns the bookmarks in an Epiphany profile.
+// Epiphany organizes the bookmarks with tags instead of folders, the tags of a bookmark are returned as its folder.
+func ListBookmarks(profilePath string) ([]api.BookMark, error) {
+	filename := filepath.Join(profilePath, "bookmarks.gvdb")
+	data, err := system.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	root, err := readGvdb(data)
+	if err != nil {
+		return nil, err
+	}
+	table, err := root.table("bookmarks")
+	if errors.Is(err, errGvdbNotFound) {
+		return []api.BookMark{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	values, err := table.values()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []api.BookMark{}
+	for url, value := range values {
+		if value.typ != bookmarkType {
+			return nil, errors.New("epiphany: unsupported bookmark type " + value.typ)
+		}
+		r := newTupleReader(value.data, 2)
+		timeAdded := r.int64()
+		title := r.string()
+		_ = r.string()  // id
+		_ = r.float64() // server time modified
+		_ = r.bool()    // uploaded
+		tags := r.stringArray()
+		if r.err != nil {
+			return nil, r.err
+		}
+		bm := api.BookMark{
+			Name:   title,
+			URL:    url,
+			Folder: tags,
+		}
+		if timeAdded != 0 {
+			bm.DateAdded = fromDbDate(timeAdded)
+		}
+		result = append(result, bm)
+	}
+	slices.SortFunc(result, func(a, b api.BookMark) int {
+		return strings.Compare(a.URL, b.URL)
+	})
+	return result, nil
+}
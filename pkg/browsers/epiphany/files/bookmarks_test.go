@@ -0,0 +1,137 @@
+package files
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListBookmarks(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	basePath := filepath.Join(os.Getenv("HOME"), ".local", "share", "epiphany")
+	system.WriteFile(filepath.Join(basePath, "bookmarks.gvdb"), buildBookmarksGvdb(map[string][]byte{
+		"https://www.redhat.com":  encodeBookmark(1745826734000000, "RedHat", "id1", []string{"Work", "Linux"}),
+		"https://www.gnome.org/":  encodeBookmark(1745826735000000, "GNOME", "id2", []string{}),
+		"https://github.com/gvdb": encodeBookmark(0, "gvdb", "id3", []string{"Favorites"}),
+	}), 0644)
+
+	bookmarks, err := ListBookmarks(basePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []api.BookMark{
+		{
+			Name:   "gvdb",
+			URL:    "https://github.com/gvdb",
+			Folder: []string{"Favorites"},
+		},
+		{
+			Name:      "GNOME",
+			URL:       "https://www.gnome.org/",
+			Folder:    []string{},
+			DateAdded: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:52:15Z")),
+		},
+		{
+			Name:      "RedHat",
+			URL:       "https://www.redhat.com",
+			Folder:    []string{"Work", "Linux"},
+			DateAdded: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:52:14Z")),
+		},
+	}
+	if diff := cmp.Diff(expected, bookmarks, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("Bookmarks differ:\n%s", diff)
+	}
+}
+
+func TestListBookmarksInvalidFile(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	basePath := filepath.Join(os.Getenv("HOME"), ".local", "share", "epiphany")
+	system.WriteFile(filepath.Join(basePath, "bookmarks.gvdb"), []byte("not a gvdb file, but long enough"), 0644)
+
+	_, err := ListBookmarks(basePath)
+	if err == nil {
+		t.Errorf("Expected error for invalid file")
+	}
+}
+
+// encodeBookmark serializes a bookmark as Epiphany does, wrapped into a variant
+func encodeBookmark(timeAdded int64, title string, id string, tags []string) []byte {
+	var b []byte
+	b = binary.LittleEndian.AppendUint64(b, uint64(timeAdded))
+	b = append(append(b, title...), 0)
+	titleEnd := len(b)
+	b = append(append(b, id...), 0)
+	idEnd := len(b)
+	for len(b)%8 != 0 {
+		b = append(b, 0)
+	}
+	b = binary.LittleEndian.AppendUint64(b, math.Float64bits(0))
+	b = append(b, 1)
+	b = append(b, encodeStringArray(tags)...)
+	// framing offsets of the variable-size members, in reverse order
+	b = append(b, byte(idEnd), byte(titleEnd))
+	return append(append(b, 0), bookmarkType...)
+}
+
+func encodeStringArray(values []string) []byte {
+	var b []byte
+	var ends []byte
+	for _, value := range values {
+		b = append(append(b, value...), 0)
+		ends = append(ends, byte(len(b)))
+	}
+	return append(b, ends...)
+}
+
+// buildBookmarksGvdb builds a GVDB file containing the values in a "bookmarks" table
+func buildBookmarksGvdb(values map[string][]byte) []byte {
+	buf := make([]byte, gvdbHeaderSize)
+	appendData := func(data []byte) (uint32, uint32) {
+		for len(buf)%8 != 0 {
+			buf = append(buf, 0)
+		}
+		start := len(buf)
+		buf = append(buf, data...)
+		return uint32(start), uint32(len(buf))
+	}
+	item := func(typ byte, key string, start uint32, end uint32) []byte {
+		keyStart, _ := appendData([]byte(key))
+		var b []byte
+		b = binary.LittleEndian.AppendUint32(b, 0)
+		b = binary.LittleEndian.AppendUint32(b, gvdbNoParent)
+		b = binary.LittleEndian.AppendUint32(b, keyStart)
+		b = binary.LittleEndian.AppendUint16(b, uint16(len(key)))
+		b = append(b, typ, 0)
+		b = binary.LittleEndian.AppendUint32(b, start)
+		return binary.LittleEndian.AppendUint32(b, end)
+	}
+	table := func(items [][]byte) (uint32, uint32) {
+		// no bloom filter, one bucket
+		data := []byte{0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}
+		for _, item := range items {
+			data = append(data, item...)
+		}
+		return appendData(data)
+	}
+
+	var items [][]byte
+	for key, value := range values {
+		start, end := appendData(value)
+		items = append(items, item('v', key, start, end))
+	}
+	bookmarksStart, bookmarksEnd := table(items)
+	rootStart, rootEnd := table([][]byte{item('H', "bookmarks", bookmarksStart, bookmarksEnd)})
+
+	copy(buf, gvdbSignature)
+	binary.LittleEndian.PutUint32(buf[16:], rootStart)
+	binary.LittleEndian.PutUint32(buf[20:], rootEnd)
+	return buf
+}
@@ -0,0 +1,20 @@
+package files
+
+import (
+	"database/sql"
+	"path/filepath"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+func fromDbDate(dbDate int64) time.Time {
+	return time.Unix(dbDate/1_000_000, 0)
+}
+func toDbDate(d time.Time) int64 {
+	return d.Unix() * 1_000_000
+}
+
+func getDb(profilePath string) (*sql.DB, error) {
+	return system.OpenDatabase(filepath.Join(profilePath, "ephy-history.db"))
+}
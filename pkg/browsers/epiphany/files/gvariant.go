@@ -0,0 +1,182 @@
+package files
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// Minimal decoder for the GVariant serialization format, limited to the types used by Epiphany
+// See https://developer.gnome.org/documentation/specifications/gvariant-specification-1.0.html
+
+type gvariant struct {
+	typ  string
+	data []byte
+}
+
+// unwrapVariant returns the value contained in a serialized variant ("v" type),
+// made of the serialized value, a zero byte and the type string of the value
+func unwrapVariant(data []byte) (gvariant, error) {
+	i := bytes.LastIndexByte(data, 0)
+	if i < 0 {
+		return gvariant{}, errors.New("gvariant: invalid variant")
+	}
+	return gvariant{typ: string(data[i+1:]), data: data[:i]}, nil
+}
+
+// offsetSize returns the size of the framing offsets of a container of the given size
+func offsetSize(size int) int {
+	switch {
+	case size == 0:
+		return 0
+	case size <= math.MaxUint8:
+		return 1
+	case size <= math.MaxUint16:
+		return 2
+	default:
+		return 4
+	}
+}
+
+func readOffset(data []byte, size int) int {
+	switch size {
+	case 1:
+		return int(data[0])
+	case 2:
+		return int(binary.LittleEndian.Uint16(data))
+	default:
+		return int(binary.LittleEndian.Uint32(data))
+	}
+}
+
+func align(offset int, alignment int) int {
+	return (offset + alignment - 1) &^ (alignment - 1)
+}
+
+// gvariantReader reads the members of a tuple in sequence
+type gvariantReader struct {
+	data   []byte
+	offset int
+	// framing offsets of the variable-size members, in the order of the members
+	frames      []int
+	frameSize   int
+	framesStart int
+	err         error
+}
+
+// newTupleReader returns a reader for a tuple containing nVariable members of variable size,
+// the last member being excluded from this count as it has no framing offset
+func newTupleReader(data []byte, nVariable int) *gvariantReader {
+	size := offsetSize(len(data))
+	r := &gvariantReader{data: data, frameSize: size, framesStart: len(data) - nVariable*size}
+	if r.framesStart < 0 {
+		r.err = errors.New("gvariant: invalid tuple")
+		return r
+	}
+	// framing offsets are stored in reverse order at the end of the tuple
+	for i := 0; i < nVariable; i++ {
+		start := len(data) - (i+1)*size
+		r.frames = append(r.frames, readOffset(data[start:], size))
+	}
+	return r
+}
+
+func (o *gvariantReader) fixed(alignment int, size int) []byte {
+	if o.err != nil {
+		return nil
+	}
+	o.offset = align(o.offset, alignment)
+	if o.offset+size > o.framesStart {
+		o.err = errors.New("gvariant: invalid fixed-size member")
+		return nil
+	}
+	result := o.data[o.offset : o.offset+size]
+	o.offset += size
+	return result
+}
+
+func (o *gvariantReader) int64() int64 {
+	data := o.fixed(8, 8)
+	if data == nil {
+		return 0
+	}
+	return int64(binary.LittleEndian.Uint64(data))
+}
+
+func (o *gvariantReader) float64() float64 {
+	data := o.fixed(8, 8)
+	if data == nil {
+		return 0
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(data))
+}
+
+func (o *gvariantReader) bool() bool {
+	data := o.fixed(1, 1)
+	if data == nil {
+		return false
+	}
+	return data[0] != 0
+}
+
+// variable returns the next member of variable size
+func (o *gvariantReader) variable() []byte {
+	if o.err != nil {
+		return nil
+	}
+	end := o.framesStart
+	if len(o.frames) > 0 {
+		end = o.frames[0]
+		o.frames = o.frames[1:]
+	}
+	if end < o.offset || end > o.framesStart {
+		o.err = errors.New("gvariant: invalid variable-size member")
+		return nil
+	}
+	result := o.data[o.offset:end]
+	o.offset = end
+	return result
+}
+
+func (o *gvariantReader) string() string {
+	return decodeString(o.variable())
+}
+
+func (o *gvariantReader) stringArray() []string {
+	data := o.variable()
+	if o.err != nil {
+		return nil
+	}
+	result, err := decodeStringArray(data)
+	if err != nil {
+		o.err = err
+	}
+	return result
+}
+
+func decodeString(data []byte) string {
+	return string(bytes.TrimSuffix(data, []byte{0}))
+}
+
+func decodeStringArray(data []byte) ([]string, error) {
+	if len(data) == 0 {
+		return []string{}, nil
+	}
+	size := offsetSize(len(data))
+	framesStart := readOffset(data[len(data)-size:], size)
+	if framesStart > len(data) || (len(data)-framesStart)%size != 0 {
+		return nil, errors.New("gvariant: invalid array")
+	}
+	result := []string{}
+	start := 0
+	for i := framesStart; i < len(data); i += size {
+		end := readOffset(data[i:], size)
+		if end < start || end > framesStart {
+			return nil, errors.New("gvariant: invalid array element")
+		}
+		result = append(result, decodeString(data[start:end]))
+		start = end
+	}
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package files
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Minimal reader for the GVDB format (GVariant database) used by Epiphany to store bookmarks.
+// See https://gitlab.gnome.org/GNOME/gvdb/-/blob/main/gvdb/gvdb-format.h
+
+var (
+	gvdbSignature   = []byte("GVariant")
+	gvdbHeaderSize  = 24
+	gvdbItemSize    = 24
+	gvdbNoParent    = uint32(0xffffffff)
+	errGvdbNotFound = errors.New("gvdb: key not found")
+)
+
+// gvdbTable is a hash table in a GVDB file
+type gvdbTable struct {
+	data  []byte
+	items []gvdbItem
+}
+
+type gvdbItem struct {
+	parent   uint32
+	keyStart uint32
+	keySize  uint16
+	typ      byte
+	start    uint32
+	end      uint32
+}
+
+// readGvdb returns the root table of a GVDB file
+func readGvdb(data []byte) (*gvdbTable, error) {
+	if len(data) < gvdbHeaderSize {
+		return nil, errors.New("gvdb: file too short")
+	}
+	if !bytes.Equal(data[:8], gvdbSignature) {
+		return nil, errors.New("gvdb: invalid signature, or unsupported byte order")
+	}
+	start := binary.LittleEndian.Uint32(data[16:20])
+	end := binary.LittleEndian.Uint32(data[20:24])
+	return newGvdbTable(data, start, end)
+}
+
+func newGvdbTable(data []byte, start uint32, end uint32) (*gvdbTable, error) {
+	if start > end || int(end) > len(data) || end-start < 8 {
+		return nil, fmt.Errorf("gvdb: invalid table pointer [%d, %d]", start, end)
+	}
+	table := data[start:end]
+	nBloomWords := binary.LittleEndian.Uint32(table[0:4]) & (1<<27 - 1)
+	nBuckets := binary.LittleEndian.Uint32(table[4:8])
+	itemsStart := 8 + 4*int(nBloomWords) + 4*int(nBuckets)
+	if itemsStart > len(table) || (len(table)-itemsStart)%gvdbItemSize != 0 {
+		return nil, errors.New("gvdb: invalid hash table")
+	}
+
+	result := &gvdbTable{data: data}
+	for offset := itemsStart; offset < len(table); offset += gvdbItemSize {
+		item := table[offset : offset+gvdbItemSize]
+		result.items = append(result.items, gvdbItem{
+			parent:   binary.LittleEndian.Uint32(item[4:8]),
+			keyStart: binary.LittleEndian.Uint32(item[8:12]),
+			keySize:  binary.LittleEndian.Uint16(item[12:14]),
+			typ:      item[14],
+			start:    binary.LittleEndian.Uint32(item[16:20]),
+			end:      binary.LittleEndian.Uint32(item[20:24]),
+		})
+	}
+	return result, nil
+}
+
+// key returns the full key of the item at index i, including the keys of its parents
+func (o *gvdbTable) key(i int) (string, error) {
+	var key []byte
+	for depth := 0; ; depth++ {
+		if i >= len(o.items) || depth > len(o.items) {
+			return "", errors.New("gvdb: invalid item parent")
+		}
+		item := o.items[i]
+		end := int(item.keyStart) + int(item.keySize)
+		if end > len(o.data) {
+			return "", errors.New("gvdb: invalid key pointer")
+		}
+		key = append(append([]byte{}, o.data[item.keyStart:end]...), key...)
+		if item.parent == gvdbNoParent {
+			return string(key), nil
+		}
+		i = int(item.parent)
+	}
+}
+
+// table returns the sub-table stored under the key
+func (o *gvdbTable) table(key string) (*gvdbTable, error) {
+	for i, item := range o.items {
+		if item.typ != 'H' {
+			continue
+		}
+		k, err := o.key(i)
+		if err != nil {
+			return nil, err
+		}
+		if k == key {
+			return newGvdbTable(o.data, item.start, item.end)
+		}
+	}
+	return nil, errGvdbNotFound
+}
+
+// values returns the serialized GVariant values of the table, indexed by their keys.
+// The values are unwrapped from the variant type they are stored in, and returned with their type string.
+func (o *gvdbTable) values() (map[string]gvariant, error) {
+	result := map[string]gvariant{}
+	for i, item := range o.items {
+		if item.typ != 'v' {
+			continue
+		}
+		key, err := o.key(i)
+		if err != nil {
+			return nil, err
+		}
+		if item.start > item.end || int(item.end) > len(o.data) {
+			return nil, errors.New("gvdb: invalid value pointer")
+		}
+		value, err := unwrapVariant(o.data[item.start:item.end])
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package files
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+// historyDb is the ephy-history.db database of Epiphany
+var historyDb = globaltest.Fixture{
+	Path: filepath.Join("/home/me/.local/share/epiphany", "ephy-history.db"),
+	Schema: []string{
+		`CREATE TABLE urls (id INTEGER PRIMARY KEY, host INTEGER NOT NULL REFERENCES hosts(id) ON DELETE CASCADE, url LONGVARCAR, title LONGVARCAR, sync_id LONGVARCAR, visit_count INTEGER DEFAULT 0 NOT NULL, typed_count INTEGER DEFAULT 0 NOT NULL, last_visit_time INTEGER, thumbnail_update_time INTEGER DEFAULT 0, hidden_from_overview INTEGER DEFAULT 0)`,
+		`CREATE TABLE visits (id INTEGER PRIMARY KEY, url INTEGER NOT NULL REFERENCES urls(id) ON DELETE CASCADE, visit_time INTEGER NOT NULL, visit_type INTEGER NOT NULL, referring_visit INTEGER)`,
+	},
+}
+
+func TestSearchEngineQueries(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	profilePath := historyDb.Write(t,
+		`INSERT INTO urls (id, host, url, title) VALUES
+			(1, 1, 'https://www.google.com/search?q=gnome+web&client=epiphany', 'gnome web - Google Search'),
+			(2, 2, 'https://apps.gnome.org/Epiphany/', 'Web – Apps for GNOME'),
+			(3, 1, 'https://www.google.com/search?q=gvdb', 'gvdb - Google Search')`,
+		// 2025-04-28T07:52:14Z, 2025-04-28T07:53:14Z, 2025-04-29T07:52:14Z
+		`INSERT INTO visits (id, url, visit_time, visit_type, referring_visit) VALUES
+			(1, 1, 1745826734000000, 1, NULL),
+			(2, 2, 1745826794000000, 1, 1),
+			(3, 3, 1745913134000000, 1, NULL)`,
+	)
+	startTime := globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T00:00:00Z"))
+	endTime := startTime.AddDate(0, 0, 1)
+
+	queries, err := SearchEngineQueries(profilePath, api.SearchEngineOptions{StartTime: startTime, EndTime: endTime, Limit: 10})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedQueries := []api.SearchEngineQuery{
+		{Query: "gnome web", Date: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:52:14Z")), SearchEngine: "Google"},
+	}
+	if diff := cmp.Diff(expectedQueries, queries, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("Queries differ:\n%s", diff)
+	}
+
+	pages, err := ListVisitedPagesFromSearchEngineQuery(profilePath, api.ListVisitedPagesFromSearchEngineQueryOptions{Query: "gnome web", StartTime: startTime, EndTime: endTime})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedPages := []api.VisitedPageFromSearchEngineQuery{
+		{URL: "https://apps.gnome.org/Epiphany/", Title: "Web – Apps for GNOME", Date: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:53:14Z")), SearchEngine: "Google"},
+	}
+	if diff := cmp.Diff(expectedPages, pages, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("Visited pages differ:\n%s", diff)
+	}
+}
@@ -0,0 +1,59 @@
+package files
+
+import (
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListVisitedPagesFromSourceRepos(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	profilePath := historyDb.Write(t,
+		`INSERT INTO urls (id, host, url, title) VALUES
+			(1, 1, 'https://github.com/GNOME/epiphany', 'GNOME/epiphany'),
+			(2, 1, 'https://github.com/GNOME/epiphany/issues/42', 'Issue 42'),
+			(3, 1, 'https://github.com/GNOME/epiphany/pulls', 'Pull requests')`,
+		`INSERT INTO visits (id, url, visit_time, visit_type) VALUES
+			(1, 1, 1745826734000000, 1),
+			(2, 1, 1745826735000000, 1),
+			(3, 2, 1745826736000000, 1),
+			(4, 3, 1745913134000000, 1)`,
+	)
+	startTime := globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T00:00:00Z"))
+	endTime := startTime.AddDate(0, 0, 1)
+
+	number := "42"
+	for _, tt := range []struct {
+		name     string
+		pageType api.SourceRepoPageType
+		expected []api.VisitedPageFromSourceRepos
+	}{
+		{
+			name: "all types",
+			expected: []api.VisitedPageFromSourceRepos{
+				{Times: 2, Provider: "github", URL: "https://github.com/GNOME/epiphany", Organization: "GNOME", Repository: "epiphany", Type: api.SourceRepoPageTypeRepositoryHome},
+				{Times: 1, Provider: "github", URL: "https://github.com/GNOME/epiphany/issues/42", Organization: "GNOME", Repository: "epiphany", Type: api.SourceRepoPageTypeIssue, Number: &number},
+			},
+		},
+		{
+			name:     "issues only",
+			pageType: api.SourceRepoPageTypeIssue,
+			expected: []api.VisitedPageFromSourceRepos{
+				{Times: 1, Provider: "github", URL: "https://github.com/GNOME/epiphany/issues/42", Organization: "GNOME", Repository: "epiphany", Type: api.SourceRepoPageTypeIssue, Number: &number},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			visits, err := ListVisitedPagesFromSourceRepos(profilePath, api.ListVisitedPagesFromSourceReposOptions{Type: tt.pageType, StartTime: startTime, EndTime: endTime})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, visits); diff != "" {
+				t.Errorf("Visits differ:\n%s", diff)
+			}
+		})
+	}
+}
@@ -8,9 +8,9 @@ import (
 	_ "modernc.org/sqlite"
 )
 
-func ListBookmarks(profile string, isRelative bool) ([]api.BookMark, error) {
+func ListBookmarks(profilePath string) ([]api.BookMark, error) {
 	result := []api.BookMark{}
-	db, err := getDb(profile, isRelative)
+	db, err := getDb(profilePath)
 	if err != nil {
 		return nil, err
 	}
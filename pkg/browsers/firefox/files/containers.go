@@ -0,0 +1,61 @@
+package files
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+type containers struct {
+	Identities []struct {
+		UserContextID int    `json:"userContextId"`
+		Public        bool   `json:"public"`
+		Name          string `json:"name"`
+		L10nID        string `json:"l10nID"`
+	} `json:"identities"`
+}
+
+// names of the default containers, which are localized by Firefox
+var defaultContainerNames = map[string]string{
+	"user-context-personal": "Personal",
+	"user-context-work":     "Work",
+	"user-context-banking":  "Banking",
+	"user-context-shopping": "Shopping",
+}
+
+// ReadContainers returns the names of the containers of the profile, indexed by their user context IDs,
+// as declared in the `containers.json` file of the profile.
+// A missing file is not an error, as the file is created when the containers are first used.
+func ReadContainers(profilePath string) (map[int]string, error) {
+	data, err := system.ReadFile(filepath.Join(profilePath, "containers.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[int]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var c containers
+	if err = json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	result := map[int]string{}
+	for _, identity := range c.Identities {
+		if !identity.Public {
+			// internal containers (thumbnails, web extensions storage, ...)
+			continue
+		}
+		name := identity.Name
+		if name == "" {
+			name = defaultContainerNames[identity.L10nID]
+		}
+		if name == "" {
+			name = strings.TrimPrefix(identity.L10nID, "user-context-")
+		}
+		result[identity.UserContextID] = name
+	}
+	return result, nil
+}
@@ -2,9 +2,10 @@ package files
 
 import (
 	"database/sql"
-	"fmt"
 	"path/filepath"
 	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
 )
 
 func fromDbDate(dbDate int64) time.Time {
@@ -14,10 +15,50 @@ func toDbDate(d time.Time) int64 {
 	return d.Unix() * 1_000_000
 }
 
-func getDb(profile string, isRelative bool) (*sql.DB, error) {
-	if isRelative {
-		profile = filepath.Join(getUserDataDirecory(), profile)
-	}
-	path := filepath.Join(profile, "places.sqlite")
-	return sql.Open("sqlite", fmt.Sprintf("file:%s?immutable=1", path))
+func getDb(profilePath string) (*sql.DB, error) {
+	return openDb(filepath.Join(profilePath, "places.sqlite"))
+}
+
+func openDb(path string) (*sql.DB, error) {
+	return system.OpenDatabase(path)
+}
+
+// historyQueries are the queries run on the places.sqlite database, adapted to the version of its schema
+type historyQueries struct {
+	searchEngineQueries               string
+	visitedPagesFromSearchEngineQuery string
+	visitedPagesFromSourceRepos       string
+}
+
+// historySchema lists the versions of the places.sqlite database supported, declared in its `user_version` pragma
+var historySchema = system.Schema[historyQueries]{
+	Database:     "firefox places.sqlite",
+	VersionQuery: `PRAGMA user_version`,
+	Ranges: []system.SchemaRange[historyQueries]{
+		{
+			// 33 is the oldest version of the places.sqlite fixtures of the tests
+			MinVersion: 33,
+			MaxVersion: 51,
+			Queries: historyQueries{
+				searchEngineQueries:               searchEngineQueriesSQL,
+				visitedPagesFromSearchEngineQuery: visitedPagesFromSearchEngineQuerySQL,
+				visitedPagesFromSourceRepos:       visitedPagesFromSourceReposSQL,
+			},
+		},
+		{
+			// version 52 added the moz_origins table, referenced by the origin_id column of moz_places
+			MinVersion: 52,
+			Queries: historyQueries{
+				searchEngineQueries:               searchEngineQueriesByOriginSQL,
+				visitedPagesFromSearchEngineQuery: visitedPagesFromSearchEngineQueryByOriginSQL,
+				visitedPagesFromSourceRepos:       visitedPagesFromSourceReposSQL,
+			},
+		},
+	},
+	// MaxTestedVersion is the version of the places.sqlite fixture of the tests
+	MaxTestedVersion: 77,
+}
+
+func getHistoryDb(profilePath string) (*system.SchemaDatabase[historyQueries], error) {
+	return historySchema.Open(filepath.Join(profilePath, "places.sqlite"))
 }
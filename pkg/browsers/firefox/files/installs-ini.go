@@ -0,0 +1,54 @@
+package files
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+
+	"gopkg.in/ini.v1"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+// Install is an installation of the browser (release, Developer Edition, Nightly, ...) sharing the root directory.
+// Its ID is a hash of its installation directory.
+type Install struct {
+	ID string
+	// Default is the path of the default profile of the install, as declared in the ini files
+	Default string
+	Locked  bool
+}
+
+// ReadInstallsIni reads the installs declared in the `installs.ini` file of a root directory.
+// A missing `installs.ini` file is not an error, as older versions do not write it.
+func ReadInstallsIni(root string) ([]Install, error) {
+	path := filepath.Join(root, "installs.ini")
+	data, err := system.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	f, err := ini.Load(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var installs []Install
+	for _, section := range f.Sections() {
+		if section.Name() == ini.DefaultSection {
+			continue
+		}
+		installs = append(installs, readInstall(section, section.Name()))
+	}
+	return installs, nil
+}
+
+func readInstall(section *ini.Section, id string) Install {
+	return Install{
+		ID:      id,
+		Default: section.Key("Default").String(),
+		Locked:  section.Key("Locked").MustBool(),
+	}
+}
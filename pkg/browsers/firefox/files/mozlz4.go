@@ -0,0 +1,52 @@
+package files
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	"github.com/pierrec/lz4/v4"
+)
+
+// mozLz4Magic starts the files compressed with the mozLz4 format (`.jsonlz4`, `.mozlz4`, `.baklz4`)
+var mozLz4Magic = []byte("mozLz40\x00")
+
+// maximum size of the decompressed data, to protect against corrupted files
+const maxMozLz4Size = 512 << 20
+
+// ReadMozLz4 reads and decompresses a file in mozLz4 format
+func ReadMozLz4(path string) ([]byte, error) {
+	data, err := system.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	result, err := DecodeMozLz4(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return result, nil
+}
+
+// DecodeMozLz4 decompresses data in mozLz4 format:
+// the magic, the size of the decompressed data (4 bytes, little-endian), then a lz4 block.
+func DecodeMozLz4(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, mozLz4Magic) {
+		return nil, errors.New("not a mozLz4 file")
+	}
+	data = data[len(mozLz4Magic):]
+	if len(data) < 4 {
+		return nil, errors.New("truncated mozLz4 file")
+	}
+	size := binary.LittleEndian.Uint32(data)
+	if size > maxMozLz4Size {
+		return nil, fmt.Errorf("mozLz4 file too large (%d bytes)", size)
+	}
+	result := make([]byte, size)
+	n, err := lz4.UncompressBlock(data[4:], result)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mozLz4 file: %w", err)
+	}
+	return result[:n], nil
+}
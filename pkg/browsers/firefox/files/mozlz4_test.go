@@ -0,0 +1,43 @@
+package files
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+// encodeMozLz4 compresses data in mozLz4 format, as Firefox does
+func encodeMozLz4(t *testing.T, data []byte) []byte {
+	t.Helper()
+	compressed := make([]byte, lz4.CompressBlockBound(len(data)))
+	n, err := lz4.CompressBlock(data, compressed, nil)
+	if err != nil {
+		t.Fatalf("failed to compress: %v", err)
+	}
+	result := append([]byte{}, mozLz4Magic...)
+	result = binary.LittleEndian.AppendUint32(result, uint32(len(data)))
+	return append(result, compressed[:n]...)
+}
+
+func TestDecodeMozLz4(t *testing.T) {
+	content := []byte(`{"windows":[{"tabs":[{"entries":[{"url":"https://www.mozilla.org/","title":"Mozilla"}],"index":1}]}]}`)
+	decoded, err := DecodeMozLz4(encodeMozLz4(t, content))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(decoded) != string(content) {
+		t.Errorf("expected %s, got %s", content, decoded)
+	}
+
+	for name, data := range map[string][]byte{
+		"not a mozLz4 file":     []byte(`{"windows":[]}`),
+		"truncated mozLz4 file": append(append([]byte{}, mozLz4Magic...), 1, 0),
+		"invalid mozLz4 file":   append(append([]byte{}, mozLz4Magic...), 10, 0, 0, 0, 0xff, 0xff),
+		"mozLz4 file too large": append(append([]byte{}, mozLz4Magic...), 0xff, 0xff, 0xff, 0xff),
+	} {
+		if _, err := DecodeMozLz4(data); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
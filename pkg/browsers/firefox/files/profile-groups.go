@@ -0,0 +1,115 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+// profileGroup is a profile group read from its database, kept until the database is modified
+type profileGroup struct {
+	modified time.Time
+	profiles []Profile
+}
+
+// profileGroups are the profile groups read, indexed by the paths of their databases
+var (
+	profileGroups   = map[string]profileGroup{}
+	profileGroupsMu sync.Mutex
+)
+
+// ReadProfileGroup reads the profiles of a profile group, created with the profile manager of Firefox,
+// from the `Profile Groups/<storeID>.sqlite` database of a root directory.
+// A missing database is not an error, as the profile group may not have been created yet.
+// The profiles are read again only when the database has been modified since the last read.
+func ReadProfileGroup(root string, storeID string) ([]Profile, error) {
+	path := filepath.Join(root, "Profile Groups", storeID+".sqlite")
+	if _, err := system.FileSystem.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	modified := system.LastModified(path, path+"-wal")
+	profileGroupsMu.Lock()
+	defer profileGroupsMu.Unlock()
+	if group, ok := profileGroups[path]; ok && group.modified.Equal(modified) {
+		return group.profiles, nil
+	}
+	profiles, err := readProfileGroup(path, storeID)
+	if err != nil {
+		return nil, err
+	}
+	profileGroups[path] = profileGroup{modified: modified, profiles: profiles}
+	return profiles, nil
+}
+
+func readProfileGroup(path string, storeID string) ([]Profile, error) {
+	db, err := openDb(path)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT id, path, name, avatar, themeId FROM Profiles ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []Profile
+	for rows.Next() {
+		var id int
+		var profilePath string
+		profile := Profile{StoreID: storeID}
+		if err = rows.Scan(&id, &profilePath, &profile.Name, &profile.Avatar, &profile.Theme); err != nil {
+			return nil, err
+		}
+		// paths of the profiles inside the root directory are stored relative to it, with slashes
+		profile.IsRelative = !filepath.IsAbs(profilePath)
+		profile.Path = profilePath
+		profiles = append(profiles, profile)
+	}
+	return profiles, rows.Err()
+}
+
+// mergeProfileGroup merges the profiles of a profile group with the profiles declared in `profiles.ini`.
+// The names of the profiles are taken from the profile group,
+// the profiles missing from `profiles.ini` are appended.
+// As the profile manager does not require unique names, a name already used by a previous profile
+// is suffixed with the directory of the profile.
+func mergeProfileGroup(profiles []Profile, group []Profile) []Profile {
+	nextID := 0
+	for _, profile := range profiles {
+		nextID = max(nextID, profile.ID+1)
+	}
+	for _, groupProfile := range group {
+		found := false
+		for i := range profiles {
+			if profiles[i].IsRelative != groupProfile.IsRelative ||
+				filepath.Clean(filepath.FromSlash(profiles[i].Path)) != filepath.Clean(filepath.FromSlash(groupProfile.Path)) {
+				continue
+			}
+			profiles[i].Name = groupProfile.Name
+			profiles[i].StoreID = groupProfile.StoreID
+			profiles[i].Avatar = groupProfile.Avatar
+			profiles[i].Theme = groupProfile.Theme
+			found = true
+		}
+		if !found {
+			groupProfile.ID = nextID
+			nextID++
+			profiles = append(profiles, groupProfile)
+		}
+	}
+	names := map[string]bool{}
+	for i := range profiles {
+		if names[profiles[i].Name] {
+			profiles[i].Name = fmt.Sprintf("%s (%s)", profiles[i].Name, filepath.Base(filepath.FromSlash(profiles[i].Path)))
+		}
+		names[profiles[i].Name] = true
+	}
+	return profiles
+}
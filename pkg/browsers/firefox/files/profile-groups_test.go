@@ -0,0 +1,187 @@
+package files
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+// profileGroupDb is the database of the profile group a1b2c3d4, in the root directory /firefox
+var profileGroupDb = globaltest.Fixture{
+	Path: filepath.Join("/firefox", "Profile Groups", "a1b2c3d4.sqlite"),
+	Schema: []string{
+		`CREATE TABLE Profiles (id INTEGER PRIMARY KEY, path TEXT NOT NULL UNIQUE, name TEXT NOT NULL, avatar TEXT NOT NULL, themeId TEXT NOT NULL, themeFg TEXT NOT NULL, themeBg TEXT NOT NULL)`,
+	},
+}
+
+func TestReadProfilesIniWithProfileGroup(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	basePath := "/firefox"
+	system.WriteFile(filepath.Join(basePath, "profiles.ini"), []byte(`[General]
+StartWithLastProfile=1
+Version=2
+StoreID=a1b2c3d4
+
+[Profile1]
+Name=legacy
+IsRelative=1
+Path=efgh.legacy
+
+[Profile0]
+Name=Original profile
+IsRelative=1
+Path=Profiles/abcd.Original profile
+StoreID=a1b2c3d4
+Default=1
+`), 0644)
+
+	profiles, err := ReadProfilesIni(basePath)
+	if err != nil {
+		t.Fatalf("Expected no error without profile group database, got %v", err)
+	}
+	if len(profiles) != 2 {
+		t.Fatalf("Expected 2 profiles, got %v", profiles)
+	}
+
+	profileGroupDb.Write(t,
+		`INSERT INTO Profiles (path, name, avatar, themeId, themeFg, themeBg) VALUES
+			('Profiles/abcd.Original profile', 'Personal', 'book', 'default-theme@mozilla.org', '', ''),
+			('Profiles/ijkl.Work', 'Work', 'briefcase', 'expressionist-soft-colorway@mozilla.org', '', ''),
+			('/data/shopping', 'Shopping', 'shopping', 'default-theme@mozilla.org', '', '')`,
+	)
+
+	profiles, err = ReadProfilesIni(basePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []Profile{
+		{
+			ID:         1,
+			Name:       "legacy",
+			IsRelative: true,
+			Path:       "efgh.legacy",
+		},
+		{
+			ID:         0,
+			Name:       "Personal",
+			IsRelative: true,
+			Path:       "Profiles/abcd.Original profile",
+			Default:    true,
+			StoreID:    "a1b2c3d4",
+			Avatar:     "book",
+			Theme:      "default-theme@mozilla.org",
+		},
+		{
+			ID:         2,
+			Name:       "Work",
+			IsRelative: true,
+			Path:       "Profiles/ijkl.Work",
+			StoreID:    "a1b2c3d4",
+			Avatar:     "briefcase",
+			Theme:      "expressionist-soft-colorway@mozilla.org",
+		},
+		{
+			ID:      3,
+			Name:    "Shopping",
+			Path:    "/data/shopping",
+			StoreID: "a1b2c3d4",
+			Avatar:  "shopping",
+			Theme:   "default-theme@mozilla.org",
+		},
+	}
+	if diff := cmp.Diff(expected, profiles); diff != "" {
+		t.Errorf("profiles mismatch (-want +got):\n%s", diff)
+	}
+	if expected := filepath.Join(basePath, "Profiles", "ijkl.Work"); profiles[2].FullPath(basePath) != expected {
+		t.Errorf("Expected %s, got %s", expected, profiles[2].FullPath(basePath))
+	}
+}
+
+func TestReadProfilesIniWithInvalidProfileGroup(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	basePath := "/firefox"
+	system.WriteFile(filepath.Join(basePath, "profiles.ini"), []byte(`[General]
+StartWithLastProfile=1
+Version=2
+StoreID=a1b2c3d4
+
+[Profile0]
+Name=Original profile
+IsRelative=1
+Path=Profiles/abcd.Original profile
+StoreID=a1b2c3d4
+Default=1
+`), 0644)
+	system.WriteFile(filepath.Join(basePath, "Profile Groups", "a1b2c3d4.sqlite"), []byte("not a database"), 0644)
+
+	profiles, err := ReadProfilesIni(basePath)
+	if err != nil {
+		t.Fatalf("Expected no error with an invalid profile group database, got %v", err)
+	}
+	expected := []Profile{
+		{
+			ID:         0,
+			Name:       "Original profile",
+			IsRelative: true,
+			Path:       "Profiles/abcd.Original profile",
+			Default:    true,
+			StoreID:    "a1b2c3d4",
+		},
+	}
+	if diff := cmp.Diff(expected, profiles); diff != "" {
+		t.Errorf("profiles mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMergeProfileGroupWithSameNames(t *testing.T) {
+	profiles := []Profile{
+		{ID: 0, Name: "Work", IsRelative: true, Path: "abcd.Work"},
+	}
+	group := []Profile{
+		{Name: "Work", IsRelative: true, Path: "Profiles/efgh.Work", StoreID: "a1b2c3d4"},
+		{Name: "Work", Path: "/data/work", StoreID: "a1b2c3d4"},
+	}
+	profiles = mergeProfileGroup(profiles, group)
+	expected := []string{"Work", "Work (efgh.Work)", "Work (work)"}
+	var names []string
+	for _, profile := range profiles {
+		names = append(names, profile.Name)
+	}
+	if diff := cmp.Diff(expected, names); diff != "" {
+		t.Errorf("names mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadProfileGroupCache(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	basePath := "/firefox"
+	path := profileGroupDb.Path
+	profileGroupDb.Write(t,
+		`INSERT INTO Profiles (path, name, avatar, themeId, themeFg, themeBg) VALUES ('Profiles/ijkl.Work', 'Work', 'briefcase', 'default-theme@mozilla.org', '', '')`,
+	)
+	modified := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	system.FileSystem.Chtimes(path, modified, modified)
+
+	profiles, err := ReadProfileGroup(basePath, "a1b2c3d4")
+	if err != nil || len(profiles) != 1 {
+		t.Fatalf("Expected 1 profile, got %v, %v", profiles, err)
+	}
+
+	// the database is not read again while it is not modified
+	system.WriteFile(path, []byte("not a database"), 0644)
+	system.FileSystem.Chtimes(path, modified, modified)
+	profiles, err = ReadProfileGroup(basePath, "a1b2c3d4")
+	if err != nil || len(profiles) != 1 {
+		t.Fatalf("Expected the cached profile, got %v, %v", profiles, err)
+	}
+
+	modified = modified.Add(time.Hour)
+	system.FileSystem.Chtimes(path, modified, modified)
+	if _, err = ReadProfileGroup(basePath, "a1b2c3d4"); err == nil {
+		t.Errorf("Expected an error once the database is modified")
+	}
+}
@@ -3,9 +3,11 @@ package files
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/log"
 	"gopkg.in/ini.v1"
 
 	"github.com/feloy/browsers-mcp-server/pkg/system"
@@ -17,10 +19,17 @@ type Profile struct {
 	IsRelative bool
 	Path       string
 	Default    bool
+	// Installs are the IDs of the installs using this profile as their default profile
+	Installs []string
+	// StoreID is the ID of the profile group the profile belongs to, if created with the profile manager
+	StoreID string
+	Avatar  string
+	Theme   string
 }
 
-func ReadProfilesIni() ([]Profile, error) {
-	path := filepath.Join(getUserDataDirecory(), "profiles.ini")
+// ReadProfilesIni reads the profiles declared in the `profiles.ini` file of a root directory.
+func ReadProfilesIni(root string) ([]Profile, error) {
+	path := filepath.Join(root, "profiles.ini")
 	data, err := system.ReadFile(path)
 	if err != nil {
 		return nil, err
@@ -31,6 +40,13 @@ func ReadProfilesIni() ([]Profile, error) {
 	}
 
 	var profiles []Profile
+	var storeIDs []string
+
+	// installs declared in profiles.ini take precedence over the ones declared in installs.ini
+	installs, err := ReadInstallsIni(root)
+	if err != nil {
+		return nil, err
+	}
 
 	sections := f.Sections()
 	for _, section := range sections {
@@ -42,10 +58,46 @@ func ReadProfilesIni() ([]Profile, error) {
 			}
 			profiles = append(profiles, *profile)
 		}
+		if storeID := section.Key("StoreID").String(); storeID != "" && !slices.Contains(storeIDs, storeID) {
+			storeIDs = append(storeIDs, storeID)
+		}
+		if strings.HasPrefix(name, "Install") {
+			install := readInstall(section, strings.TrimPrefix(name, "Install"))
+			installs = slices.DeleteFunc(installs, func(i Install) bool {
+				return i.ID == install.ID
+			})
+			installs = append(installs, install)
+		}
+	}
+
+	// profiles.ini only declares the default profile of a profile group
+	for _, storeID := range storeIDs {
+		group, err := ReadProfileGroup(root, storeID)
+		if err != nil {
+			log.Warn("unable to read profile group, using the profiles declared in profiles.ini", "root", root, "store_id", storeID, "error", err)
+			continue
+		}
+		profiles = mergeProfileGroup(profiles, group)
+	}
+
+	for _, install := range installs {
+		for i := range profiles {
+			if profiles[i].Path == install.Default {
+				profiles[i].Installs = append(profiles[i].Installs, install.ID)
+			}
+		}
 	}
 	return profiles, nil
 }
 
+// FullPath returns the path of the directory containing the files of the profile
+func (o Profile) FullPath(root string) string {
+	if o.IsRelative {
+		return filepath.Join(root, o.Path)
+	}
+	return o.Path
+}
+
 func readProfile(section *ini.Section, id string) (*Profile, error) {
 	n, err := strconv.Atoi(id)
 	if err != nil {
@@ -57,5 +109,6 @@ func readProfile(section *ini.Section, id string) (*Profile, error) {
 		IsRelative: section.Key("IsRelative").MustBool(),
 		Path:       section.Key("Path").String(),
 		Default:    section.Key("Default").MustBool(),
+		StoreID:    section.Key("StoreID").String(),
 	}, nil
 }
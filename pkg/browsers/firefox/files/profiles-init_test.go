@@ -6,11 +6,12 @@ import (
 	"testing"
 
 	"github.com/feloy/browsers-mcp-server/pkg/system"
-	"github.com/spf13/afero"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestReadProfilesIni(t *testing.T) {
-	system.FileSystem = afero.NewMemMapFs()
+	globaltest.MemFileSystem(t)
 	system.Os = "darwin"
 	basePath := filepath.Join(os.Getenv("HOME"), "Library", "Application Support", "Firefox")
 	system.WriteFile(filepath.Join(basePath, "profiles.ini"), []byte(`[Profile0]
@@ -19,7 +20,7 @@ IsRelative=1
 Path=path/to/profile
 Default=1
 `), 0644)
-	profiles, err := ReadProfilesIni()
+	profiles, err := ReadProfilesIni(basePath)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -39,3 +40,79 @@ Default=1
 		t.Errorf("Expected Default to be true, got %v", profiles[0].Default)
 	}
 }
+
+func TestReadProfilesIniWithInstalls(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	basePath := filepath.Join(os.Getenv("HOME"), ".mozilla", "firefox")
+	system.WriteFile(filepath.Join(basePath, "profiles.ini"), []byte(`[InstallAAAA]
+Default=abcd.default-release
+Locked=1
+
+[Profile0]
+Name=default-release
+IsRelative=1
+Path=abcd.default-release
+
+[Profile1]
+Name=dev-edition-default
+IsRelative=1
+Path=efgh.dev-edition-default
+
+[Profile2]
+Name=default
+IsRelative=1
+Path=ijkl.default
+Default=1
+`), 0644)
+	system.WriteFile(filepath.Join(basePath, "installs.ini"), []byte(`[AAAA]
+Default=ijkl.default
+
+[BBBB]
+Default=efgh.dev-edition-default
+Locked=1
+`), 0644)
+
+	profiles, err := ReadProfilesIni(basePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := map[string][]string{
+		"default-release":     {"AAAA"},
+		"dev-edition-default": {"BBBB"},
+		"default":             nil,
+	}
+	if len(profiles) != len(expected) {
+		t.Fatalf("Expected %d profiles, got %d", len(expected), len(profiles))
+	}
+	for _, profile := range profiles {
+		if !cmp.Equal(expected[profile.Name], profile.Installs) {
+			t.Errorf("Expected installs %v for profile %s, got %v", expected[profile.Name], profile.Name, profile.Installs)
+		}
+	}
+}
+
+func TestReadInstallsIni(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	basePath := filepath.Join(os.Getenv("HOME"), ".librewolf")
+
+	installs, err := ReadInstallsIni(basePath)
+	if err != nil {
+		t.Errorf("Expected no error for missing installs.ini, got %v", err)
+	}
+	if len(installs) != 0 {
+		t.Errorf("Expected no install, got %v", installs)
+	}
+
+	system.WriteFile(filepath.Join(basePath, "installs.ini"), []byte(`[6C4A4B34C1F8E8D3]
+Default=wxyz.default-default
+Locked=1
+`), 0644)
+	installs, err = ReadInstallsIni(basePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []Install{{ID: "6C4A4B34C1F8E8D3", Default: "wxyz.default-default", Locked: true}}
+	if !cmp.Equal(expected, installs) {
+		t.Errorf("Expected %v, got %v", expected, installs)
+	}
+}
@@ -6,13 +6,38 @@ import (
 	"github.com/feloy/browsers-mcp-server/pkg/api"
 )
 
-func SearchEngineQueries(profile string, isRelative bool, options api.SearchEngineOptions) ([]api.SearchEngineQuery, error) {
+const searchEngineQueriesSQL = `SELECT
+  visit_date,
+	url 
+FROM moz_historyvisits hv
+INNER JOIN moz_places p ON p.id = hv.place_id 
+WHERE url LIKE 'https://www.google.com/search%'
+AND hv.visit_date >= ?
+AND hv.visit_date < ?
+ORDER BY hv.visit_date ASC
+LIMIT ?`
+
+// searchEngineQueriesByOriginSQL selects the places of the search results by their origin, using the index of moz_places on origin_id
+const searchEngineQueriesByOriginSQL = `SELECT
+  visit_date,
+	url 
+FROM moz_historyvisits hv
+INNER JOIN moz_places p ON p.id = hv.place_id 
+INNER JOIN moz_origins o ON o.id = p.origin_id
+WHERE o.host = 'www.google.com'
+AND url LIKE 'https://www.google.com/search%'
+AND hv.visit_date >= ?
+AND hv.visit_date < ?
+ORDER BY hv.visit_date ASC
+LIMIT ?`
+
+func SearchEngineQueries(profilePath string, options api.SearchEngineOptions) ([]api.SearchEngineQuery, error) {
 	type queryResult struct {
 		VisitDate int64
 		URL       string
 	}
 
-	db, err := getDb(profile, isRelative)
+	db, err := getHistoryDb(profilePath)
 	if err != nil {
 		return nil, err
 	}
@@ -20,18 +45,9 @@ func SearchEngineQueries(profile string, isRelative bool, options api.SearchEngi
 
 	startTime := toDbDate(options.StartTime)
 	endTime := toDbDate(options.EndTime)
-	rows, err := db.Query(`SELECT
-  visit_date,
-	url 
-FROM moz_historyvisits hv
-INNER JOIN moz_places p ON p.id = hv.place_id 
-WHERE url LIKE 'https://www.google.com/search%'
-AND hv.visit_date >= ?
-AND hv.visit_date < ?
-ORDER BY hv.visit_date ASC
-LIMIT ?`, startTime, endTime, options.Limit)
+	rows, err := db.Query(db.Queries.searchEngineQueries, startTime, endTime, options.Limit)
 	if err != nil {
-		return nil, err
+		return nil, db.Err(err)
 	}
 	defer rows.Close()
 
@@ -57,14 +73,45 @@ LIMIT ?`, startTime, endTime, options.Limit)
 	return searchEngineQueries, nil
 }
 
-func ListVisitedPagesFromSearchEngineQuery(profile string, isRelative bool, options api.ListVisitedPagesFromSearchEngineQueryOptions) ([]api.VisitedPageFromSearchEngineQuery, error) {
+const visitedPagesFromSearchEngineQuerySQL = `SELECT
+  visited.visit_date,
+	visited_place.url,
+	visited_place.title
+FROM moz_historyvisits hv
+INNER JOIN moz_places p ON p.id = hv.place_id 
+INNER JOIN moz_historyvisits visited ON visited.from_visit = hv.id
+INNER JOIN moz_places visited_place ON visited_place.id = visited.place_id
+WHERE p.url LIKE 'https://www.google.com/search%'
+AND (? = '' OR p.url like ? OR p.url like ?)
+AND hv.visit_date >= ?
+AND hv.visit_date < ?
+ORDER BY hv.visit_date ASC`
+
+// visitedPagesFromSearchEngineQueryByOriginSQL selects the places of the search results by their origin, using the index of moz_places on origin_id
+const visitedPagesFromSearchEngineQueryByOriginSQL = `SELECT
+  visited.visit_date,
+	visited_place.url,
+	visited_place.title
+FROM moz_historyvisits hv
+INNER JOIN moz_places p ON p.id = hv.place_id 
+INNER JOIN moz_origins o ON o.id = p.origin_id
+INNER JOIN moz_historyvisits visited ON visited.from_visit = hv.id
+INNER JOIN moz_places visited_place ON visited_place.id = visited.place_id
+WHERE o.host = 'www.google.com'
+AND p.url LIKE 'https://www.google.com/search%'
+AND (? = '' OR p.url like ? OR p.url like ?)
+AND hv.visit_date >= ?
+AND hv.visit_date < ?
+ORDER BY hv.visit_date ASC`
+
+func ListVisitedPagesFromSearchEngineQuery(profilePath string, options api.ListVisitedPagesFromSearchEngineQueryOptions) ([]api.VisitedPageFromSearchEngineQuery, error) {
 	type queryResult struct {
 		VisitTime int64
 		URL       string
 		Title     string
 	}
 
-	db, err := getDb(profile, isRelative)
+	db, err := getHistoryDb(profilePath)
 	if err != nil {
 		return nil, err
 	}
@@ -72,21 +119,9 @@ func ListVisitedPagesFromSearchEngineQuery(profile string, isRelative bool, opti
 
 	startTime := toDbDate(options.StartTime)
 	endTime := toDbDate(options.EndTime)
-	rows, err := db.Query(`SELECT
-  visited.visit_date,
-	visited_place.url,
-	visited_place.title
-FROM moz_historyvisits hv
-INNER JOIN moz_places p ON p.id = hv.place_id 
-INNER JOIN moz_historyvisits visited ON visited.from_visit = hv.id
-INNER JOIN moz_places visited_place ON visited_place.id = visited.place_id
-WHERE p.url LIKE 'https://www.google.com/search%'
-AND (? = '' OR p.url like ? OR p.url like ?)
-AND hv.visit_date >= ?
-AND hv.visit_date < ?
-ORDER BY hv.visit_date ASC`, options.Query, "%q="+url.QueryEscape(options.Query)+"&%", "%q="+url.QueryEscape(options.Query), startTime, endTime)
+	rows, err := db.Query(db.Queries.visitedPagesFromSearchEngineQuery, options.Query, "%q="+url.QueryEscape(options.Query)+"&%", "%q="+url.QueryEscape(options.Query), startTime, endTime)
 	if err != nil {
-		return nil, err
+		return nil, db.Err(err)
 	}
 	defer rows.Close()
 
@@ -0,0 +1,128 @@
+package files
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+// placesDb is the places.sqlite database of a Firefox profile
+var placesDb = globaltest.Fixture{
+	Path: filepath.Join("/profiles/abcd.default-release", "places.sqlite"),
+	Schema: []string{
+		`PRAGMA user_version = 77`,
+		`CREATE TABLE moz_origins (id INTEGER PRIMARY KEY, prefix TEXT NOT NULL, host TEXT NOT NULL, frecency INTEGER NOT NULL, UNIQUE (prefix, host))`,
+		`CREATE TABLE moz_places (id INTEGER PRIMARY KEY, url LONGVARCHAR, title LONGVARCHAR, rev_host LONGVARCHAR, visit_count INTEGER DEFAULT 0, hidden INTEGER DEFAULT 0 NOT NULL, typed INTEGER DEFAULT 0 NOT NULL, frecency INTEGER DEFAULT -1 NOT NULL, last_visit_date INTEGER, guid TEXT, foreign_count INTEGER DEFAULT 0 NOT NULL, url_hash INTEGER DEFAULT 0 NOT NULL, description TEXT, preview_image_url TEXT, origin_id INTEGER REFERENCES moz_origins(id))`,
+		`CREATE TABLE moz_historyvisits (id INTEGER PRIMARY KEY, from_visit INTEGER, place_id INTEGER, visit_date INTEGER, visit_type INTEGER, session INTEGER, source INTEGER DEFAULT 0 NOT NULL, triggeringPlaceId INTEGER)`,
+	},
+}
+
+// placesDbWithoutOrigins is the places.sqlite database of the versions without the moz_origins table
+var placesDbWithoutOrigins = globaltest.Fixture{
+	Path: placesDb.Path,
+	Schema: []string{
+		`PRAGMA user_version = 51`,
+		`CREATE TABLE moz_places (id INTEGER PRIMARY KEY, url LONGVARCHAR, title LONGVARCHAR, rev_host LONGVARCHAR, visit_count INTEGER DEFAULT 0, hidden INTEGER DEFAULT 0 NOT NULL, typed INTEGER DEFAULT 0 NOT NULL, frecency INTEGER DEFAULT -1 NOT NULL, last_visit_date INTEGER, guid TEXT, foreign_count INTEGER DEFAULT 0 NOT NULL, url_hash INTEGER DEFAULT 0 NOT NULL)`,
+		`CREATE TABLE moz_historyvisits (id INTEGER PRIMARY KEY, from_visit INTEGER, place_id INTEGER, visit_date INTEGER, visit_type INTEGER, session INTEGER)`,
+	},
+}
+
+func TestSearchEngineQueries(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	profilePath := placesDb.Write(t,
+		`INSERT INTO moz_origins (id, prefix, host, frecency) VALUES
+			(1, 'https://', 'www.google.com', 0),
+			(2, 'https://', 'searchfox.org', 0)`,
+		`INSERT INTO moz_places (id, url, title, origin_id) VALUES
+			(1, 'https://www.google.com/search?client=firefox-b-d&q=mozlz4', 'mozlz4 - Google Search', 1),
+			(2, 'https://searchfox.org/mozilla-central/', 'mozilla-central - Searchfox', 2),
+			(3, 'https://www.google.com/search?q=gecko', 'gecko - Google Search', 1)`,
+		// 2025-04-28T07:52:14Z, 2025-04-28T07:53:14Z, 2025-04-29T07:52:14Z
+		`INSERT INTO moz_historyvisits (id, from_visit, place_id, visit_date, visit_type) VALUES
+			(1, 0, 1, 1745826734000000, 1),
+			(2, 1, 2, 1745826794000000, 1),
+			(3, 0, 3, 1745913134000000, 1)`,
+	)
+	startTime := globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T00:00:00Z"))
+	endTime := startTime.AddDate(0, 0, 1)
+	timeComparer := cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })
+
+	queries, err := SearchEngineQueries(profilePath, api.SearchEngineOptions{StartTime: startTime, EndTime: endTime, Limit: 10})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedQueries := []api.SearchEngineQuery{
+		{Query: "mozlz4", Date: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:52:14Z")), SearchEngine: "Google"},
+	}
+	if diff := cmp.Diff(expectedQueries, queries, timeComparer); diff != "" {
+		t.Errorf("Queries differ:\n%s", diff)
+	}
+
+	pages, err := ListVisitedPagesFromSearchEngineQuery(profilePath, api.ListVisitedPagesFromSearchEngineQueryOptions{Query: "mozlz4", StartTime: startTime, EndTime: endTime})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedPages := []api.VisitedPageFromSearchEngineQuery{
+		{URL: "https://searchfox.org/mozilla-central/", Title: "mozilla-central - Searchfox", Date: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:53:14Z")), SearchEngine: "Google"},
+	}
+	if diff := cmp.Diff(expectedPages, pages, timeComparer); diff != "" {
+		t.Errorf("Visited pages differ:\n%s", diff)
+	}
+}
+
+func TestSearchEngineQueriesWithoutOrigins(t *testing.T) {
+	startTime := globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T00:00:00Z"))
+	endTime := startTime.AddDate(0, 0, 1)
+	timeComparer := cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })
+
+	for _, version := range []string{"33", "51"} {
+		t.Run(version, func(t *testing.T) {
+			globaltest.MemFileSystem(t)
+			profilePath := placesDbWithoutOrigins.Write(t,
+				`PRAGMA user_version = `+version,
+				`INSERT INTO moz_places (id, url, title) VALUES
+					(1, 'https://www.google.com/search?client=firefox-b-d&q=mozlz4', 'mozlz4 - Google Search'),
+					(2, 'https://searchfox.org/mozilla-central/', 'mozilla-central - Searchfox')`,
+				// 2025-04-28T07:52:14Z, 2025-04-28T07:53:14Z
+				`INSERT INTO moz_historyvisits (id, from_visit, place_id, visit_date, visit_type) VALUES
+					(1, 0, 1, 1745826734000000, 1),
+					(2, 1, 2, 1745826794000000, 1)`,
+			)
+
+			queries, err := SearchEngineQueries(profilePath, api.SearchEngineOptions{StartTime: startTime, EndTime: endTime, Limit: 10})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			expectedQueries := []api.SearchEngineQuery{
+				{Query: "mozlz4", Date: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:52:14Z")), SearchEngine: "Google"},
+			}
+			if diff := cmp.Diff(expectedQueries, queries, timeComparer); diff != "" {
+				t.Errorf("Queries differ:\n%s", diff)
+			}
+
+			pages, err := ListVisitedPagesFromSearchEngineQuery(profilePath, api.ListVisitedPagesFromSearchEngineQueryOptions{Query: "mozlz4", StartTime: startTime, EndTime: endTime})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			expectedPages := []api.VisitedPageFromSearchEngineQuery{
+				{URL: "https://searchfox.org/mozilla-central/", Title: "mozilla-central - Searchfox", Date: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:53:14Z")), SearchEngine: "Google"},
+			}
+			if diff := cmp.Diff(expectedPages, pages, timeComparer); diff != "" {
+				t.Errorf("Visited pages differ:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSearchEngineQueriesUnsupportedSchema(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	profilePath := placesDb.Write(t, `PRAGMA user_version = 20`)
+	_, err := SearchEngineQueries(profilePath, api.SearchEngineOptions{StartTime: time.Now().AddDate(0, 0, -1), EndTime: time.Now(), Limit: 10})
+	if err == nil || err.Error() != "unsupported schema version 20 for firefox places.sqlite" {
+		t.Errorf("Expected unsupported schema version error, got %v", err)
+	}
+}
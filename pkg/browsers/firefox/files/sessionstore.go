@@ -0,0 +1,171 @@
+package files
+
+import (
+	"encoding/json"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+// SessionStore contains the windows and tabs of a Firefox session
+type SessionStore struct {
+	Windows       []SessionWindow `json:"windows"`
+	ClosedWindows []SessionWindow `json:"_closedWindows"`
+}
+
+type SessionWindow struct {
+	Tabs       []SessionTab       `json:"tabs"`
+	ClosedTabs []SessionClosedTab `json:"_closedTabs"`
+	Groups     []SessionTabGroup  `json:"groups"`
+	// Title and ClosedAt are set for the closed windows only
+	Title    string `json:"title"`
+	ClosedAt int64  `json:"closedAt"`
+}
+
+type SessionTab struct {
+	Entries []SessionEntry `json:"entries"`
+	// Index is the index of the current entry, starting at 1
+	Index         int    `json:"index"`
+	LastAccessed  int64  `json:"lastAccessed"`
+	Pinned        bool   `json:"pinned"`
+	Hidden        bool   `json:"hidden"`
+	UserContextID int    `json:"userContextId"`
+	GroupID       string `json:"groupId"`
+}
+
+type SessionEntry struct {
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
+
+type SessionClosedTab struct {
+	State    SessionTab `json:"state"`
+	Title    string     `json:"title"`
+	ClosedAt int64      `json:"closedAt"`
+}
+
+type SessionTabGroup struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// ReadSessionStore reads the session of a profile, from the recovery file written while Firefox is running,
+// or from the file written when Firefox exits, whichever is the most recent.
+func ReadSessionStore(profilePath string) (*SessionStore, error) {
+	var path string
+	var lastModified time.Time
+	for _, candidate := range []string{
+		filepath.Join(profilePath, "sessionstore-backups", "recovery.jsonlz4"),
+		filepath.Join(profilePath, "sessionstore.jsonlz4"),
+	} {
+		info, err := system.FileSystem.Stat(candidate)
+		if err != nil {
+			continue
+		}
+		if path == "" || info.ModTime().After(lastModified) {
+			path = candidate
+			lastModified = info.ModTime()
+		}
+	}
+	if path == "" {
+		return nil, &fs.PathError{Op: "open", Path: filepath.Join(profilePath, "sessionstore.jsonlz4"), Err: fs.ErrNotExist}
+	}
+	data, err := ReadMozLz4(path)
+	if err != nil {
+		return nil, err
+	}
+	var session SessionStore
+	if err = json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListOpenTabs returns the tabs open in the session, except the hidden ones.
+// The containers are indexed by their user context IDs.
+func (o *SessionStore) ListOpenTabs(containers map[int]string) []api.Tab {
+	result := []api.Tab{}
+	for i, window := range o.Windows {
+		for _, tab := range window.Tabs {
+			if tab.Hidden {
+				continue
+			}
+			apiTab := tab.toTab(containers, window.Groups)
+			apiTab.Window = i + 1
+			result = append(result, apiTab)
+		}
+	}
+	return result
+}
+
+// ListRecentlyClosed returns the tabs closed in the open windows, and the closed windows with their tabs
+func (o *SessionStore) ListRecentlyClosed(containers map[int]string) api.RecentlyClosed {
+	result := api.RecentlyClosed{
+		Tabs:    []api.ClosedTab{},
+		Windows: []api.ClosedWindow{},
+	}
+	for _, window := range o.Windows {
+		for _, closedTab := range window.ClosedTabs {
+			tab := closedTab.State.toTab(containers, window.Groups)
+			if tab.Title == "" {
+				tab.Title = closedTab.Title
+			}
+			result.Tabs = append(result.Tabs, api.ClosedTab{
+				Title:    tab.Title,
+				URL:      tab.URL,
+				ClosedAt: fromSessionDate(closedTab.ClosedAt),
+			})
+		}
+	}
+	for _, window := range o.ClosedWindows {
+		closedWindow := api.ClosedWindow{
+			Title:    window.Title,
+			ClosedAt: fromSessionDate(window.ClosedAt),
+			Tabs:     []api.Tab{},
+		}
+		for _, tab := range window.Tabs {
+			closedWindow.Tabs = append(closedWindow.Tabs, tab.toTab(containers, window.Groups))
+		}
+		result.Windows = append(result.Windows, closedWindow)
+	}
+	return result
+}
+
+// toTab returns the current entry of the tab, with its back/forward history
+func (o SessionTab) toTab(containers map[int]string, groups []SessionTabGroup) api.Tab {
+	tab := api.Tab{
+		Pinned:       o.Pinned,
+		Container:    containers[o.UserContextID],
+		LastAccessed: fromSessionDate(o.LastAccessed),
+	}
+	if len(o.Entries) > 0 {
+		index := min(max(o.Index, 1), len(o.Entries)) - 1
+		tab.Title = o.Entries[index].Title
+		tab.URL = o.Entries[index].URL
+		for _, entry := range o.Entries[:index] {
+			tab.Back = append(tab.Back, api.TabEntry{Title: entry.Title, URL: entry.URL})
+		}
+		for _, entry := range o.Entries[index+1:] {
+			tab.Forward = append(tab.Forward, api.TabEntry{Title: entry.Title, URL: entry.URL})
+		}
+	}
+	for _, group := range groups {
+		if o.GroupID != "" && group.ID == o.GroupID {
+			tab.Group = group.Name
+			tab.GroupColor = group.Color
+		}
+	}
+	return tab
+}
+
+// fromSessionDate converts the dates of the session, in milliseconds since the Unix epoch
+func fromSessionDate(date int64) time.Time {
+	if date == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(date).UTC()
+}
@@ -0,0 +1,158 @@
+package files
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+const sessionFixture = `{
+  "version": ["sessionrestore", 1],
+  "windows": [
+    {
+      "tabs": [
+        {
+          "entries": [
+            { "url": "https://www.mozilla.org/", "title": "Mozilla" },
+            { "url": "https://www.mozilla.org/firefox/", "title": "Firefox" }
+          ],
+          "index": 2,
+          "lastAccessed": 1745826734000,
+          "pinned": true,
+          "userContextId": 0
+        },
+        {
+          "entries": [{ "url": "https://mail.example.com/", "title": "Mail" }],
+          "index": 1,
+          "lastAccessed": 1745826794000,
+          "userContextId": 2,
+          "groupId": "1745826700000-1"
+        },
+        {
+          "entries": [{ "url": "https://hidden.example.com/", "title": "Hidden" }],
+          "index": 1,
+          "hidden": true
+        }
+      ],
+      "groups": [{ "id": "1745826700000-1", "name": "Office", "color": "blue", "collapsed": false }],
+      "_closedTabs": [
+        {
+          "state": {
+            "entries": [{ "url": "https://news.example.com/", "title": "News" }],
+            "index": 1
+          },
+          "title": "News",
+          "closedAt": 1745826800000
+        }
+      ]
+    },
+    {
+      "tabs": [
+        {
+          "entries": [{ "url": "https://bank.example.com/", "title": "Bank" }],
+          "index": 1,
+          "userContextId": 5
+        }
+      ]
+    }
+  ],
+  "_closedWindows": [
+    {
+      "title": "Recipes",
+      "closedAt": 1745826900000,
+      "tabs": [
+        {
+          "entries": [{ "url": "https://recipes.example.com/", "title": "Recipes" }],
+          "index": 1
+        }
+      ]
+    }
+  ]
+}`
+
+func TestSessionStore(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	profilePath := "/profiles/abcd.default-release"
+	// the most recent file is read
+	system.WriteFile(filepath.Join(profilePath, "sessionstore.jsonlz4"), encodeMozLz4(t, []byte(`{"windows": []}`)), 0644)
+	system.FileSystem.Chtimes(filepath.Join(profilePath, "sessionstore.jsonlz4"), time.Unix(1745826000, 0), time.Unix(1745826000, 0))
+	system.WriteFile(filepath.Join(profilePath, "sessionstore-backups", "recovery.jsonlz4"), encodeMozLz4(t, []byte(sessionFixture)), 0644)
+	system.WriteFile(filepath.Join(profilePath, "containers.json"), []byte(`{
+  "version": 5,
+  "lastUserContextId": 5,
+  "identities": [
+    { "userContextId": 2, "public": true, "icon": "briefcase", "color": "orange", "l10nID": "user-context-work" },
+    { "userContextId": 5, "public": true, "icon": "dollar", "color": "green", "name": "Money" },
+    { "userContextId": 4294967295, "public": false, "name": "userContextIdInternal.thumbnail" }
+  ]
+}`), 0644)
+
+	session, err := ReadSessionStore(profilePath)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	containers, err := ReadContainers(profilePath)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := map[int]string{2: "Work", 5: "Money"}; !cmp.Equal(expected, containers) {
+		t.Errorf("expected containers %v, got %v", expected, containers)
+	}
+
+	expectedTabs := []api.Tab{
+		{
+			Title:        "Firefox",
+			URL:          "https://www.mozilla.org/firefox/",
+			Window:       1,
+			Pinned:       true,
+			LastAccessed: time.Date(2025, 4, 28, 7, 52, 14, 0, time.UTC),
+			Back:         []api.TabEntry{{Title: "Mozilla", URL: "https://www.mozilla.org/"}},
+		},
+		{
+			Title:        "Mail",
+			URL:          "https://mail.example.com/",
+			Window:       1,
+			Container:    "Work",
+			Group:        "Office",
+			GroupColor:   "blue",
+			LastAccessed: time.Date(2025, 4, 28, 7, 53, 14, 0, time.UTC),
+		},
+		{
+			Title:     "Bank",
+			URL:       "https://bank.example.com/",
+			Window:    2,
+			Container: "Money",
+		},
+	}
+	if diff := cmp.Diff(expectedTabs, session.ListOpenTabs(containers)); diff != "" {
+		t.Errorf("open tabs mismatch (-want +got):\n%s", diff)
+	}
+
+	expectedClosed := api.RecentlyClosed{
+		Tabs: []api.ClosedTab{
+			{Title: "News", URL: "https://news.example.com/", ClosedAt: time.Date(2025, 4, 28, 7, 53, 20, 0, time.UTC)},
+		},
+		Windows: []api.ClosedWindow{
+			{
+				Title:    "Recipes",
+				ClosedAt: time.Date(2025, 4, 28, 7, 55, 0, 0, time.UTC),
+				Tabs:     []api.Tab{{Title: "Recipes", URL: "https://recipes.example.com/"}},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedClosed, session.ListRecentlyClosed(containers)); diff != "" {
+		t.Errorf("recently closed mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err = ReadSessionStore("/profiles/missing"); err == nil {
+		t.Error("expected an error for a profile without session")
+	}
+	if containers, err = ReadContainers("/profiles/missing"); err != nil || len(containers) != 0 {
+		t.Errorf("expected no containers, got %v, %v", containers, err)
+	}
+}
@@ -0,0 +1,36 @@
+package files
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+type signedInUser struct {
+	AccountData struct {
+		Email string `json:"email"`
+	} `json:"accountData"`
+}
+
+// ReadAccount returns the email of the Mozilla account the profile is signed in with,
+// as declared in the `signedInUser.json` file of the profile.
+// An empty string is returned when the profile is not signed in.
+func ReadAccount(profilePath string) (string, error) {
+	path := filepath.Join(profilePath, "signedInUser.json")
+	data, err := system.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	var user signedInUser
+	err = json.Unmarshal(data, &user)
+	if err != nil {
+		return "", err
+	}
+	return user.AccountData.Email, nil
+}
@@ -0,0 +1,61 @@
+package files
+
+import (
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListVisitedPagesFromSourceRepos(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	profilePath := placesDb.Write(t,
+		`INSERT INTO moz_places (id, url, title) VALUES
+			(1, 'https://github.com/mozilla', 'Mozilla'),
+			(2, 'https://github.com/mozilla/gecko-dev/discussions/7', 'Discussion 7'),
+			(3, 'https://github.com/mozilla/gecko-dev/issues?q=is%3Aopen', 'Issues')`,
+		`INSERT INTO moz_historyvisits (id, place_id, visit_date, visit_type) VALUES
+			(1, 1, 1745826734000000, 1),
+			(2, 2, 1745826794000000, 1),
+			(3, 3, 1745826795000000, 1),
+			(4, 3, 1745826796000000, 1),
+			(5, 3, 1745913134000000, 1)`,
+	)
+	startTime := globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T00:00:00Z"))
+	endTime := startTime.AddDate(0, 0, 1)
+
+	number := "7"
+	for _, tt := range []struct {
+		name     string
+		pageType api.SourceRepoPageType
+		expected []api.VisitedPageFromSourceRepos
+	}{
+		{
+			name: "all types",
+			expected: []api.VisitedPageFromSourceRepos{
+				{Times: 2, Provider: "github", URL: "https://github.com/mozilla/gecko-dev/issues", Organization: "mozilla", Repository: "gecko-dev", Type: api.SourceRepoPageTypeIssuesList},
+				{Times: 1, Provider: "github", URL: "https://github.com/mozilla", Organization: "mozilla", Type: api.SourceRepoPageTypeOrganizationHome},
+				{Times: 1, Provider: "github", URL: "https://github.com/mozilla/gecko-dev/discussions/7", Organization: "mozilla", Repository: "gecko-dev", Type: api.SourceRepoPageTypeDiscussion, Number: &number},
+			},
+		},
+		{
+			name:     "organization homes only",
+			pageType: api.SourceRepoPageTypeOrganizationHome,
+			expected: []api.VisitedPageFromSourceRepos{
+				{Times: 1, Provider: "github", URL: "https://github.com/mozilla", Organization: "mozilla", Type: api.SourceRepoPageTypeOrganizationHome},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			visits, err := ListVisitedPagesFromSourceRepos(profilePath, api.ListVisitedPagesFromSourceReposOptions{Type: tt.pageType, StartTime: startTime, EndTime: endTime})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, visits); diff != "" {
+				t.Errorf("Visits differ:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package files
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+// syncedTabsRecord is the record of a device, as synced by Firefox Sync
+type syncedTabsRecord struct {
+	ClientName string `json:"clientName"`
+	Tabs       []struct {
+		Title string `json:"title"`
+		// URLHistory is the history of the tab, the current URL being the first one
+		URLHistory []string `json:"urlHistory"`
+		// LastUsed is in seconds since the Unix epoch
+		LastUsed int64 `json:"lastUsed"`
+	} `json:"tabs"`
+}
+
+// ListSyncedTabs returns the tabs synced from the other devices of the user, from the `synced-tabs.db` database.
+// No device is returned when the profile does not use Firefox Sync.
+func ListSyncedTabs(profilePath string) ([]api.SyncedDevice, error) {
+	log.Debug("synced tabs", "profilePath", profilePath)
+
+	filename := filepath.Join(profilePath, "synced-tabs.db")
+	result := []api.SyncedDevice{}
+	if _, err := system.FileSystem.Stat(filename); errors.Is(err, fs.ErrNotExist) {
+		return result, nil
+	}
+	db, err := openDb(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT record, last_modified FROM tabs ORDER BY last_modified DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record string
+		var lastModified int64
+		if err = rows.Scan(&record, &lastModified); err != nil {
+			return nil, err
+		}
+		var value syncedTabsRecord
+		if err = json.Unmarshal([]byte(record), &value); err != nil {
+			return nil, err
+		}
+		device := api.SyncedDevice{
+			Name:         value.ClientName,
+			LastModified: fromSessionDate(lastModified),
+			Tabs:         []api.SyncedTab{},
+		}
+		for _, tab := range value.Tabs {
+			syncedTab := api.SyncedTab{Title: tab.Title}
+			if len(tab.URLHistory) > 0 {
+				syncedTab.URL = tab.URLHistory[0]
+			}
+			if tab.LastUsed > 0 {
+				syncedTab.LastUsed = time.Unix(tab.LastUsed, 0).UTC()
+			}
+			device.Tabs = append(device.Tabs, syncedTab)
+		}
+		result = append(result, device)
+	}
+	return result, rows.Err()
+}
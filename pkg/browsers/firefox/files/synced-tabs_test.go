@@ -0,0 +1,62 @@
+package files
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListSyncedTabs(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	profilePath := "/profiles/abcd.default-release"
+
+	t.Run("profile not using Firefox Sync", func(t *testing.T) {
+		devices, err := ListSyncedTabs(profilePath)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(devices) != 0 {
+			t.Errorf("expected no device, got %v", devices)
+		}
+	})
+
+	globaltest.WriteDatabase(t, filepath.Join(profilePath, "synced-tabs.db"),
+		`CREATE TABLE tabs (guid TEXT NOT NULL PRIMARY KEY, record TEXT NOT NULL, last_modified INTEGER NOT NULL)`,
+		// 2025-04-28T07:52:14Z, 2025-04-29T07:52:14Z
+		`INSERT INTO tabs (guid, record, last_modified) VALUES
+			('laptop-guid', '{"id":"laptop-guid","clientName":"Work laptop","tabs":[]}', 1745826734000),
+			('phone-guid', '{"id":"phone-guid","clientName":"Firefox on Pixel 8","tabs":[
+				{"title":"Lisbon trams","urlHistory":["https://trams.example.com/lisbon","https://trams.example.com/"],"icon":"","lastUsed":1745913134,"inactive":false},
+				{"title":"Untitled","urlHistory":[],"lastUsed":0}
+			]}', 1745913134000)`,
+	)
+
+	t.Run("devices of the profile", func(t *testing.T) {
+		devices, err := ListSyncedTabs(profilePath)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		expected := []api.SyncedDevice{
+			{
+				Name:         "Firefox on Pixel 8",
+				LastModified: time.Date(2025, 4, 29, 7, 52, 14, 0, time.UTC),
+				Tabs: []api.SyncedTab{
+					{Title: "Lisbon trams", URL: "https://trams.example.com/lisbon", LastUsed: time.Date(2025, 4, 29, 7, 52, 14, 0, time.UTC)},
+					{Title: "Untitled"},
+				},
+			},
+			{
+				Name:         "Work laptop",
+				LastModified: time.Date(2025, 4, 28, 7, 52, 14, 0, time.UTC),
+				Tabs:         []api.SyncedTab{},
+			},
+		}
+		if diff := cmp.Diff(expected, devices); diff != "" {
+			t.Errorf("devices mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -1,90 +1,249 @@
-package chrome
+package firefox
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 
+	"github.com/charmbracelet/log"
 	"github.com/feloy/browsers-mcp-server/pkg/api"
 	"github.com/feloy/browsers-mcp-server/pkg/browsers"
 	"github.com/feloy/browsers-mcp-server/pkg/browsers/firefox/files"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
 )
 
-var instance api.Browser = &Firefox{}
+// Gecko is a provider for Firefox and the browsers of the Gecko family,
+// which all share the `profiles.ini`, `installs.ini` and `places.sqlite` formats.
+type Gecko struct {
+	vendor Vendor
+	// wsl indicates that the provider reads the Windows install of the browser from a WSL distribution
+	wsl bool
+}
+
+func New(vendor Vendor) *Gecko {
+	return &Gecko{vendor: vendor}
+}
 
-type Firefox struct{}
+// NewWSL returns a provider for the Windows install of a browser, read from a WSL distribution
+func NewWSL(vendor Vendor) *Gecko {
+	return &Gecko{vendor: vendor, wsl: true}
+}
 
-func (o *Firefox) Name() string {
-	return "firefox"
+func (o *Gecko) Name() string {
+	if o.wsl {
+		return o.vendor.Name + " (windows)"
+	}
+	return o.vendor.Name
 }
 
-func (o *Firefox) IsAvailable() (bool, error) {
-	_, err := files.ReadProfilesIni()
+func (o *Gecko) IsAvailable() (bool, error) {
+	_, err := o.Profiles()
 	return err == nil, err
 }
 
-func (o *Firefox) Profiles() ([]string, error) {
-	profiles, err := files.ReadProfilesIni()
+func (o *Gecko) Bookmarks(profileName string) ([]api.BookMark, error) {
+	profilePath, err := o.profilePath(profileName)
 	if err != nil {
 		return nil, err
 	}
-	profileNames := []string{}
-	for _, profile := range profiles {
-		profileNames = append(profileNames, profile.Name)
+	return files.ListBookmarks(profilePath)
+}
+
+func (o *Gecko) SearchEngineQueries(profileName string, options api.SearchEngineOptions) ([]api.SearchEngineQuery, error) {
+	profilePath, err := o.profilePath(profileName)
+	if err != nil {
+		return nil, err
 	}
-	return profileNames, nil
+	return files.SearchEngineQueries(profilePath, options)
 }
 
-func (o *Firefox) Bookmarks(profileName string) ([]api.BookMark, error) {
-	profiles, err := files.ReadProfilesIni()
+func (o *Gecko) ListVisitedPagesFromSearchEngineQuery(profileName string, options api.ListVisitedPagesFromSearchEngineQueryOptions) ([]api.VisitedPageFromSearchEngineQuery, error) {
+	profilePath, err := o.profilePath(profileName)
 	if err != nil {
 		return nil, err
 	}
-	for _, profile := range profiles {
-		if profile.Name == profileName {
-			return files.ListBookmarks(profile.Path, profile.IsRelative)
-		}
+	return files.ListVisitedPagesFromSearchEngineQuery(profilePath, options)
+}
+
+func (o *Gecko) ListVisitedPagesFromSourceRepos(profileName string, options api.ListVisitedPagesFromSourceReposOptions) ([]api.VisitedPageFromSourceRepos, error) {
+	profilePath, err := o.profilePath(profileName)
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("profile %s not found", profileName)
+	return files.ListVisitedPagesFromSourceRepos(profilePath, options)
 }
 
-func (o *Firefox) SearchEngineQueries(profileName string, options api.SearchEngineOptions) ([]api.SearchEngineQuery, error) {
-	profiles, err := files.ReadProfilesIni()
+// Profiles returns the profiles of all the installs of the browser.
+// The IDs of the profiles of the Flatpak and Snap installs are suffixed with the name of the install.
+func (o *Gecko) Profiles() ([]api.Profile, error) {
+	var result []api.Profile
+	var lastErr error
+	found := false
+	installs, err := o.installs()
 	if err != nil {
 		return nil, err
 	}
-	for _, profile := range profiles {
-		if profile.Name == profileName {
-			return files.SearchEngineQueries(profile.Path, profile.IsRelative, options)
+	for _, install := range installs {
+		profiles, err := files.ReadProfilesIni(install.root)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				log.Debug("browser install not found", "browser", o.Name(), "install", install.name, "path", install.root)
+			} else {
+				log.Warn("unable to read browser install", "browser", o.Name(), "install", install.name, "path", install.root, "error", err)
+			}
+			lastErr = err
+			continue
+		}
+		found = true
+
+		var rootProfiles []api.Profile
+		for _, ffProfile := range profiles {
+			p := api.Profile{
+				ID:         ffProfile.Name,
+				Name:       ffProfile.Name,
+				Path:       ffProfile.FullPath(install.root),
+				Default:    ffProfile.Default,
+				Install:    install.name,
+				DefaultFor: ffProfile.Installs,
+			}
+			history := filepath.Join(p.Path, "places.sqlite")
+			p.LastUsed = system.LastModified(history, history+"-wal")
+			p.Account, err = files.ReadAccount(p.Path)
+			if err != nil {
+				log.Warn("unable to read account of profile", "browser", o.Name(), "profile", p.Name, "error", err)
+			}
+			if install.name != "" {
+				p.ID = fmt.Sprintf("%s (%s)", ffProfile.Name, install.name)
+			}
+			rootProfiles = append(rootProfiles, p)
+		}
+		if i := defaultOfInstalls(profiles, rootProfiles); i != -1 {
+			for j := range rootProfiles {
+				rootProfiles[j].Default = j == i
+			}
+		}
+		result = append(result, rootProfiles...)
+	}
+	if !found {
+		return nil, lastErr
+	}
+	return result, nil
+}
+
+// defaultOfInstalls returns the index of the default profile of a root directory shared by several installs,
+// or -1 when no install declares a default profile. The default profiles of the installs take precedence
+// over the legacy `Default=1` flag, and a single one is returned when the installs have different defaults:
+// the one also flagged `Default=1`, or else the most recently used one, or else the first declared one.
+func defaultOfInstalls(profiles []files.Profile, apiProfiles []api.Profile) int {
+	result := -1
+	for i, profile := range profiles {
+		if len(profile.Installs) == 0 {
+			continue
+		}
+		if result == -1 {
+			result = i
+			continue
+		}
+		if profile.Default != profiles[result].Default {
+			if profile.Default {
+				result = i
+			}
+			continue
+		}
+		if apiProfiles[i].LastUsed.After(apiProfiles[result].LastUsed) {
+			result = i
 		}
 	}
-	return nil, fmt.Errorf("profile %s not found", profileName)
+	return result
 }
 
-func (o *Firefox) ListVisitedPagesFromSearchEngineQuery(profileName string, options api.ListVisitedPagesFromSearchEngineQueryOptions) ([]api.VisitedPageFromSearchEngineQuery, error) {
-	profiles, err := files.ReadProfilesIni()
+// installs returns the locations of the profiles of the browser,
+// or the directories configured for the browser
+func (o *Gecko) installs() ([]install, error) {
+	if dirs := system.ConfiguredDataDirectories(o.Name()); len(dirs) > 0 {
+		var result []install
+		for _, dir := range dirs {
+			result = append(result, install{name: dir.Name, root: dir.Path})
+		}
+		return result, nil
+	}
+	if !o.wsl {
+		return o.vendor.installs(system.Native()), nil
+	}
+	platform, ok := system.WSL()
+	if !ok {
+		return nil, errors.New("not running in WSL")
+	}
+	return o.vendor.installs(platform), nil
+}
+
+// profilePath returns the directory containing the files of the profile
+func (o *Gecko) profilePath(profileName string) (string, error) {
+	profiles, err := o.Profiles()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	for _, profile := range profiles {
-		if profile.Name == profileName {
-			return files.ListVisitedPagesFromSearchEngineQuery(profile.Path, profile.IsRelative, options)
+		if profile.ID == profileName {
+			return profile.Path, nil
 		}
 	}
-	return nil, fmt.Errorf("profile %s not found", profileName)
+	return "", fmt.Errorf("profile %s not found", profileName)
 }
 
-func (o *Firefox) ListVisitedPagesFromSourceRepos(profileName string, options api.ListVisitedPagesFromSourceReposOptions) ([]api.VisitedPageFromSourceRepos, error) {
-	profiles, err := files.ReadProfilesIni()
+var _ api.OpenTabsReader = &Gecko{}
+
+// ListOpenTabs returns the tabs of the current session, or of the last session when the browser is not running
+func (o *Gecko) ListOpenTabs(profileName string, _ api.ListOpenTabsOptions) ([]api.Tab, error) {
+	session, containers, err := o.session(profileName)
 	if err != nil {
 		return nil, err
 	}
-	for _, profile := range profiles {
-		if profile.Name == profileName {
-			return files.ListVisitedPagesFromSourceRepos(profile.Path, profile.IsRelative, options)
-		}
+	return session.ListOpenTabs(containers), nil
+}
+
+var _ api.RecentlyClosedReader = &Gecko{}
+
+// ListRecentlyClosed returns the tabs and windows closed during the session
+func (o *Gecko) ListRecentlyClosed(profileName string) (api.RecentlyClosed, error) {
+	session, containers, err := o.session(profileName)
+	if err != nil {
+		return api.RecentlyClosed{}, err
+	}
+	return session.ListRecentlyClosed(containers), nil
+}
+
+var _ api.SyncedTabsReader = &Gecko{}
+
+func (o *Gecko) ListSyncedTabs(profileName string) ([]api.SyncedDevice, error) {
+	profilePath, err := o.profilePath(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.ListSyncedTabs(profilePath)
+}
+
+// session returns the session store of the profile, and the names of its containers
+func (o *Gecko) session(profileName string) (*files.SessionStore, map[int]string, error) {
+	profilePath, err := o.profilePath(profileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	session, err := files.ReadSessionStore(profilePath)
+	if err != nil {
+		return nil, nil, err
 	}
-	return nil, fmt.Errorf("profile %s not found", profileName)
+	containers, err := files.ReadContainers(profilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return session, containers, nil
 }
 
 func init() {
-	browsers.Register(instance)
+	for _, vendor := range Vendors {
+		browsers.Register(New(vendor))
+		browsers.Register(NewWSL(vendor))
+	}
 }
@@ -0,0 +1,267 @@
+package firefox
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/test"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProfilesFromSandboxedInstalls(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	home := os.Getenv("HOME")
+	system.WriteFile(filepath.Join(home, "snap", "firefox", "common", ".mozilla", "firefox", "profiles.ini"), []byte(`[Profile0]
+Name=default-release
+IsRelative=1
+Path=abcd.default-release
+Default=1
+`), 0644)
+	system.WriteFile(filepath.Join(home, ".var", "app", "org.mozilla.firefox", ".mozilla", "firefox", "profiles.ini"), []byte(`[Profile0]
+Name=work
+IsRelative=0
+Path=/data/work
+`), 0644)
+
+	firefox := New(Vendors[0])
+	available, err := firefox.IsAvailable()
+	if !available || err != nil {
+		t.Fatalf("expected firefox to be available, got %v, %v", available, err)
+	}
+	profiles, err := firefox.Profiles()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []string{"work (flatpak)", "default-release (snap)"}
+	if !cmp.Equal(expected, test.ProfileIDs(profiles)) {
+		t.Errorf("expected %v, got %v", expected, test.ProfileIDs(profiles))
+	}
+
+	for profileName, expectedPath := range map[string]string{
+		"work (flatpak)":         "/data/work",
+		"default-release (snap)": filepath.Join(home, "snap", "firefox", "common", ".mozilla", "firefox", "abcd.default-release"),
+	} {
+		path, err := firefox.profilePath(profileName)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if path != expectedPath {
+			t.Errorf("expected %s, got %s", expectedPath, path)
+		}
+	}
+}
+
+func TestNoInstall(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	firefox := New(Vendors[0])
+	available, _ := firefox.IsAvailable()
+	if available {
+		t.Errorf("expected firefox not to be available")
+	}
+}
+
+func TestForkProfiles(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	system.WriteFile(filepath.Join(os.Getenv("HOME"), ".librewolf", "profiles.ini"), []byte(`[Profile0]
+Name=default-default
+IsRelative=1
+Path=wxyz.default-default
+Default=1
+`), 0644)
+
+	for _, vendor := range Vendors {
+		browser := New(vendor)
+		available, _ := browser.IsAvailable()
+		if available != (vendor.Name == "librewolf") {
+			t.Errorf("unexpected availability %v for %s", available, vendor.Name)
+		}
+	}
+
+	librewolf := New(Vendor{Name: "librewolf", Linux: []string{".librewolf"}})
+	profiles, err := librewolf.Profiles()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := []string{"default-default"}; !cmp.Equal(expected, test.ProfileIDs(profiles)) {
+		t.Errorf("expected %v, got %v", expected, test.ProfileIDs(profiles))
+	}
+}
+
+func TestProfilesMetadata(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	root := filepath.Join(os.Getenv("HOME"), ".mozilla", "firefox")
+	system.WriteFile(filepath.Join(root, "profiles.ini"), []byte(`[Profile1]
+Name=default
+IsRelative=1
+Path=efgh.default
+Default=1
+
+[Profile0]
+Name=default-release
+IsRelative=1
+Path=abcd.default-release
+
+[Install4F96D1932A9F858E]
+Default=abcd.default-release
+Locked=1
+`), 0644)
+	// the install of the Developer Edition is only declared in installs.ini
+	system.WriteFile(filepath.Join(root, "installs.ini"), []byte(`[308046B0AF4A39CB]
+Default=abcd.default-release
+Locked=1
+`), 0644)
+	system.WriteFile(filepath.Join(root, "abcd.default-release", "signedInUser.json"), []byte(`{
+  "version": 1,
+  "accountData": {
+    "email": "jane@example.com",
+    "verified": true
+  }
+}`), 0644)
+	// the last use of a profile is the last modification of its history
+	lastUsed := time.Date(2025, 4, 28, 10, 0, 0, 0, time.UTC)
+	system.WriteFile(filepath.Join(root, "efgh.default", "places.sqlite"), []byte{}, 0644)
+	system.WriteFile(filepath.Join(root, "efgh.default", "places.sqlite-wal"), []byte{}, 0644)
+	system.FileSystem.Chtimes(filepath.Join(root, "efgh.default", "places.sqlite"), lastUsed.Add(-time.Hour), lastUsed.Add(-time.Hour))
+	system.FileSystem.Chtimes(filepath.Join(root, "efgh.default", "places.sqlite-wal"), lastUsed, lastUsed)
+
+	firefox := New(Vendor{Name: "firefox", Linux: []string{".mozilla", "firefox"}})
+	profiles, err := firefox.Profiles()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []api.Profile{
+		{
+			ID:       "default",
+			Name:     "default",
+			Path:     filepath.Join(root, "efgh.default"),
+			LastUsed: lastUsed,
+		},
+		{
+			ID:         "default-release",
+			Name:       "default-release",
+			Path:       filepath.Join(root, "abcd.default-release"),
+			Default:    true,
+			Account:    "jane@example.com",
+			DefaultFor: []string{"308046B0AF4A39CB", "4F96D1932A9F858E"},
+		},
+	}
+	if diff := cmp.Diff(expected, profiles); diff != "" {
+		t.Errorf("profiles mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWSLProfiles(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	t.Setenv("WSL_DISTRO_NAME", "Ubuntu")
+	t.Setenv("USER", "joe")
+	root := "/mnt/c/Users/jane/AppData/Roaming/Mozilla/Firefox"
+	system.WriteFile(filepath.Join(root, "profiles.ini"), []byte(`[Profile0]
+Name=default-release
+IsRelative=1
+Path=Profiles/abcd.default-release
+Default=1
+`), 0644)
+	system.FileSystem.MkdirAll("/mnt/c/Users/Default", 0755)
+
+	firefox := NewWSL(Vendors[0])
+	if firefox.Name() != "firefox (windows)" {
+		t.Errorf("expected firefox (windows), got %s", firefox.Name())
+	}
+	profiles, err := firefox.Profiles()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []api.Profile{
+		{
+			ID:      "default-release",
+			Name:    "default-release",
+			Path:    filepath.Join(root, "Profiles", "abcd.default-release"),
+			Default: true,
+		},
+	}
+	if diff := cmp.Diff(expected, profiles); diff != "" {
+		t.Errorf("profiles mismatch (-want +got):\n%s", diff)
+	}
+	if available, _ := New(Vendors[0]).IsAvailable(); available {
+		t.Error("expected the linux install not to be available")
+	}
+}
+
+func TestDefaultProfileOfInstalls(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	root := filepath.Join(os.Getenv("HOME"), ".mozilla", "firefox")
+	profilesIni := `[Profile0]
+Name=default-release
+IsRelative=1
+Path=abcd.default-release
+
+[Profile1]
+Name=dev-edition-default
+IsRelative=1
+Path=efgh.dev-edition-default
+
+[Install4F96D1932A9F858E]
+Default=abcd.default-release
+Locked=1
+
+[Install308046B0AF4A39CB]
+Default=efgh.dev-edition-default
+Locked=1
+`
+	// the Developer Edition profile is the most recently used one
+	lastUsed := time.Date(2025, 4, 28, 10, 0, 0, 0, time.UTC)
+	for profile, modified := range map[string]time.Time{
+		"abcd.default-release":     lastUsed.Add(-time.Hour),
+		"efgh.dev-edition-default": lastUsed,
+	} {
+		system.WriteFile(filepath.Join(root, profile, "places.sqlite"), []byte{}, 0644)
+		system.FileSystem.Chtimes(filepath.Join(root, profile, "places.sqlite"), modified, modified)
+	}
+	firefox := New(Vendor{Name: "firefox", Linux: []string{".mozilla", "firefox"}})
+
+	for _, tt := range []struct {
+		name            string
+		profilesIni     string
+		expectedDefault string
+	}{
+		{
+			name:            "most recently used default of the installs",
+			profilesIni:     profilesIni,
+			expectedDefault: "dev-edition-default",
+		},
+		{
+			name:            "default of the installs flagged Default=1",
+			profilesIni:     strings.Replace(profilesIni, "Path=abcd.default-release\n", "Path=abcd.default-release\nDefault=1\n", 1),
+			expectedDefault: "default-release",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			system.WriteFile(filepath.Join(root, "profiles.ini"), []byte(tt.profilesIni), 0644)
+			profiles, err := firefox.Profiles()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			var defaults []string
+			for _, profile := range profiles {
+				if profile.Default {
+					defaults = append(defaults, profile.ID)
+				}
+			}
+			if diff := cmp.Diff([]string{tt.expectedDefault}, defaults); diff != "" {
+				t.Errorf("default profiles mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package firefox
+
+import (
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+const (
+	installFlatpak = "flatpak"
+	installSnap    = "snap"
+)
+
+// Vendor describes where a browser of the Gecko family stores its profiles on each platform
+type Vendor struct {
+	Name string
+	// Darwin is the root directory, relative to $HOME/Library/Application Support
+	Darwin []string
+	// Windows is the root directory, relative to %APPDATA%
+	Windows []string
+	// Linux is the root directory, relative to $HOME
+	Linux []string
+	// Flatpak is the root directory of the Flatpak install on Linux, relative to $HOME/.var/app
+	Flatpak []string
+	// Snap is the root directory of the Snap install on Linux, relative to $HOME/snap
+	Snap []string
+}
+
+var Vendors = []Vendor{
+	{
+		Name:    "firefox",
+		Darwin:  []string{"Firefox"},
+		Windows: []string{"Mozilla", "Firefox"},
+		Linux:   []string{".mozilla", "firefox"},
+		Flatpak: []string{"org.mozilla.firefox", ".mozilla", "firefox"},
+		Snap:    []string{"firefox", "common", ".mozilla", "firefox"},
+	},
+	{
+		Name:    "librewolf",
+		Darwin:  []string{"librewolf"},
+		Windows: []string{"librewolf"},
+		Linux:   []string{".librewolf"},
+		Flatpak: []string{"io.gitlab.librewolf-community", ".librewolf"},
+	},
+	{
+		Name:    "waterfox",
+		Darwin:  []string{"Waterfox"},
+		Windows: []string{"Waterfox"},
+		Linux:   []string{".waterfox"},
+		Flatpak: []string{"net.waterfox.waterfox", ".waterfox"},
+	},
+	{
+		Name:    "floorp",
+		Darwin:  []string{"Floorp"},
+		Windows: []string{"Floorp"},
+		Linux:   []string{".floorp"},
+		Flatpak: []string{"one.ablaze.floorp", ".floorp"},
+	},
+	{
+		Name:    "zen",
+		Darwin:  []string{"zen"},
+		Windows: []string{"zen"},
+		Linux:   []string{".zen"},
+		Flatpak: []string{"app.zen_browser.zen", ".zen"},
+	},
+}
+
+// install is a location where a browser stores its profiles
+type install struct {
+	// name is empty for the native install
+	name string
+	root string
+}
+
+// installs returns all the known locations of the profiles of the browser on a platform
+func (o Vendor) installs(platform system.Platform) []install {
+	if platform.Os == "darwin" {
+		return []install{
+			{root: platform.HomePath(append([]string{"Library", "Application Support"}, o.Darwin...)...)},
+		}
+	}
+	if platform.Os == "windows" {
+		return []install{
+			{root: platform.AppDataPath(o.Windows...)},
+		}
+	}
+	if platform.Os == "linux" {
+		result := []install{
+			{root: platform.HomePath(o.Linux...)},
+		}
+		if len(o.Flatpak) > 0 {
+			result = append(result, install{
+				name: installFlatpak,
+				root: platform.HomePath(append([]string{".var", "app"}, o.Flatpak...)...),
+			})
+		}
+		if len(o.Snap) > 0 {
+			result = append(result, install{
+				name: installSnap,
+				root: platform.HomePath(append([]string{"snap"}, o.Snap...)...),
+			})
+		}
+		return result
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+// Package plugin provides browsers implemented by external executables, the plugins.
+//
+// For each call, the plugin is started with its configured arguments, reads a single JSON request on its standard input
+// and writes a single JSON response on its standard output, before exiting:
+//
+//	{"method": "bookmarks", "profile": "Default", "options": {...}}
+//	{"result": [...]} or {"error": "message"}
+//
+// The methods are the methods of api.Browser: `is_available` (result: boolean), `profiles`, `bookmarks`,
+// `search_engine_queries`, `visited_pages_from_search_engine_query` and `visited_pages_from_source_repos`,
+// whose results and options use the JSON names of the api types.
+// The plugin is killed when it does not exit before the timeout of the call, and is then disabled
+// for a cooldown period, so that a hanging plugin does not delay the other calls.
+// The availability and the profiles, requested for each tool call, are kept for a short time,
+// including the errors returned by the plugin.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers"
+	"github.com/feloy/browsers-mcp-server/pkg/config"
+)
+
+const (
+	MethodIsAvailable                           = "is_available"
+	MethodProfiles                              = "profiles"
+	MethodBookmarks                             = "bookmarks"
+	MethodSearchEngineQueries                   = "search_engine_queries"
+	MethodListVisitedPagesFromSearchEngineQuery = "visited_pages_from_search_engine_query"
+	MethodListVisitedPagesFromSourceRepos       = "visited_pages_from_source_repos"
+)
+
+// maximum size of the output of a plugin
+const maxOutputSize = 64 << 20
+
+const (
+	// defaultCacheTTL is the duration the availability and the profiles returned by a plugin are kept
+	defaultCacheTTL = 30 * time.Second
+	// defaultCooldown is the duration a plugin is disabled after a timeout
+	defaultCooldown = time.Minute
+)
+
+// Request is written by the server on the standard input of the plugin
+type Request struct {
+	Method  string `json:"method"`
+	Profile string `json:"profile,omitempty"`
+	Options any    `json:"options,omitempty"`
+}
+
+// Response is written by the plugin on its standard output
+type Response struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+var _ api.Browser = &Plugin{}
+
+// Plugin is a browser implemented by an external executable
+type Plugin struct {
+	name    string
+	command string
+	args    []string
+	timeout time.Duration
+
+	cacheTTL time.Duration
+	cooldown time.Duration
+
+	lock sync.Mutex
+	// available and profiles are the last results returned by the plugin
+	available cacheEntry[bool]
+	profiles  cacheEntry[[]api.Profile]
+	// disabled is the error of the call after which the plugin is disabled, until disabledUntil
+	disabled      error
+	disabledUntil time.Time
+}
+
+// cacheEntry is a result of the plugin, kept until it expires
+type cacheEntry[T any] struct {
+	value   T
+	err     error
+	expires time.Time
+}
+
+func New(cfg config.PluginConfig) *Plugin {
+	return &Plugin{
+		name:     cfg.Name,
+		command:  cfg.Command,
+		args:     cfg.Args,
+		timeout:  cfg.GetTimeout(),
+		cacheTTL: defaultCacheTTL,
+		cooldown: defaultCooldown,
+	}
+}
+
+// Register registers the configured plugins in the browsers registry.
+// A plugin having the name of a built-in browser replaces it.
+func Register(plugins []config.PluginConfig) {
+	for _, cfg := range plugins {
+		log.Info("registering browser plugin", "browser", cfg.Name, "command", cfg.Command)
+		browsers.Register(New(cfg))
+	}
+}
+
+func (o *Plugin) Name() string {
+	return o.name
+}
+
+func (o *Plugin) IsAvailable() (bool, error) {
+	return cached(o, &o.available, func() (bool, error) {
+		var available bool
+		err := o.call(Request{Method: MethodIsAvailable}, &available)
+		return available, err
+	})
+}
+
+func (o *Plugin) Profiles() ([]api.Profile, error) {
+	profiles, err := cached(o, &o.profiles, func() ([]api.Profile, error) {
+		profiles := []api.Profile{}
+		if err := o.call(Request{Method: MethodProfiles}, &profiles); err != nil {
+			return nil, err
+		}
+		if profiles == nil {
+			profiles = []api.Profile{}
+		}
+		return profiles, nil
+	})
+	return slices.Clone(profiles), err
+}
+
+// cached returns the result of a call kept in an entry, or calls the plugin when the entry has expired
+func cached[T any](o *Plugin, entry *cacheEntry[T], call func() (T, error)) (T, error) {
+	o.lock.Lock()
+	if time.Now().Before(entry.expires) {
+		value, err := entry.value, entry.err
+		o.lock.Unlock()
+		return value, err
+	}
+	o.lock.Unlock()
+
+	value, err := call()
+	o.lock.Lock()
+	*entry = cacheEntry[T]{value: value, err: err, expires: time.Now().Add(o.cacheTTL)}
+	o.lock.Unlock()
+	return value, err
+}
+
+func (o *Plugin) Bookmarks(profile string) ([]api.BookMark, error) {
+	var bookmarks []api.BookMark
+	err := o.call(Request{Method: MethodBookmarks, Profile: profile}, &bookmarks)
+	return bookmarks, err
+}
+
+func (o *Plugin) SearchEngineQueries(profile string, options api.SearchEngineOptions) ([]api.SearchEngineQuery, error) {
+	var queries []api.SearchEngineQuery
+	err := o.call(Request{Method: MethodSearchEngineQueries, Profile: profile, Options: options}, &queries)
+	return queries, err
+}
+
+func (o *Plugin) ListVisitedPagesFromSearchEngineQuery(profile string, options api.ListVisitedPagesFromSearchEngineQueryOptions) ([]api.VisitedPageFromSearchEngineQuery, error) {
+	var pages []api.VisitedPageFromSearchEngineQuery
+	err := o.call(Request{Method: MethodListVisitedPagesFromSearchEngineQuery, Profile: profile, Options: options}, &pages)
+	return pages, err
+}
+
+func (o *Plugin) ListVisitedPagesFromSourceRepos(profile string, options api.ListVisitedPagesFromSourceReposOptions) ([]api.VisitedPageFromSourceRepos, error) {
+	var pages []api.VisitedPageFromSourceRepos
+	err := o.call(Request{Method: MethodListVisitedPagesFromSourceRepos, Profile: profile, Options: options}, &pages)
+	return pages, err
+}
+
+// call runs the plugin for a request, and decodes the result of its response
+func (o *Plugin) call(request Request, result any) error {
+	o.lock.Lock()
+	disabled := o.disabled
+	disabledUntil := o.disabledUntil
+	o.lock.Unlock()
+	if disabled != nil && time.Now().Before(disabledUntil) {
+		return fmt.Errorf("%w (plugin disabled)", disabled)
+	}
+
+	input, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, o.command, o.args...)
+	cmd.Stdin = bytes.NewReader(input)
+	stdout := &limitedBuffer{limit: maxOutputSize}
+	stderr := &limitedBuffer{limit: maxOutputSize}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// processes started by the plugin may keep the outputs open
+	cmd.WaitDelay = time.Second
+
+	err = cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("plugin %s: %s timed out after %s", o.name, request.Method, o.timeout)
+		log.Warn("disabling browser plugin", "browser", o.name, "cooldown", o.cooldown, "error", err)
+		o.lock.Lock()
+		o.disabled = err
+		o.disabledUntil = time.Now().Add(o.cooldown)
+		o.lock.Unlock()
+		return err
+	}
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("plugin %s: %s failed: %w: %s", o.name, request.Method, err, message)
+		}
+		return fmt.Errorf("plugin %s: %s failed: %w", o.name, request.Method, err)
+	}
+	if stdout.truncated {
+		return fmt.Errorf("plugin %s: %s output exceeds %d bytes", o.name, request.Method, maxOutputSize)
+	}
+
+	var response Response
+	if err = json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return fmt.Errorf("plugin %s: %s returned an invalid response: %w", o.name, request.Method, err)
+	}
+	if response.Error != "" {
+		return fmt.Errorf("plugin %s: %s", o.name, response.Error)
+	}
+	if len(response.Result) == 0 {
+		return nil
+	}
+	if err = json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("plugin %s: %s returned an invalid result: %w", o.name, request.Method, err)
+	}
+	return nil
+}
+
+// limitedBuffer is a buffer dropping the data written after a limit
+type limitedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (o *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := o.limit - o.Len(); len(p) > remaining {
+		o.truncated = true
+		o.Buffer.Write(p[:max(remaining, 0)])
+		// accept the data, so that the plugin is not blocked on its output
+		return len(p), nil
+	}
+	return o.Buffer.Write(p)
+}
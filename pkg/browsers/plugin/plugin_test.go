@@ -0,0 +1,272 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/config"
+	"github.com/google/go-cmp/cmp"
+)
+
+// TestHelperProcess is not a real test, it is run as a plugin by the other tests,
+// behaving as indicated by the first argument following "--"
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	defer os.Exit(0)
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	behavior := args[1]
+
+	var request Request
+	if err := json.NewDecoder(os.Stdin).Decode(&request); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid request: %v", err)
+		os.Exit(2)
+	}
+	// record the calls, to check the ones answered from the cache
+	if calls := os.Getenv("PLUGIN_CALLS_FILE"); calls != "" {
+		f, _ := os.OpenFile(calls, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		fmt.Fprintln(f, request.Method)
+		f.Close()
+	}
+	switch behavior {
+	case "echo":
+		// the result is the request, to check what the plugin receives
+		data, _ := json.Marshal(request)
+		fmt.Printf(`{"result": %s}`, data)
+	case "browser":
+		switch request.Method {
+		case MethodIsAvailable:
+			fmt.Print(`{"result": true}`)
+		case MethodProfiles:
+			fmt.Print(`{"result": [{"id": "work", "name": "Work", "path": "/profiles/work", "default": true, "last_used": "2025-04-28T07:52:14Z"}]}`)
+		case MethodBookmarks:
+			fmt.Printf(`{"result": [{"name": "Intranet", "url": "https://intranet.example.com", "folder": [%q]}]}`, request.Profile)
+		default:
+			fmt.Printf(`{"error": "method %s not supported"}`, request.Method)
+		}
+	case "sleep":
+		time.Sleep(10 * time.Second)
+	case "crash":
+		fmt.Fprint(os.Stderr, "segmentation fault")
+		os.Exit(3)
+	case "garbage":
+		fmt.Print("not json")
+	}
+}
+
+func newHelperPlugin(t *testing.T, behavior string, timeout string) *Plugin {
+	t.Helper()
+	t.Setenv("GO_WANT_HELPER_PROCESS", "1")
+	return New(config.PluginConfig{
+		Name:    "corp",
+		Command: os.Args[0],
+		Args:    []string{"-test.run=TestHelperProcess", "--", behavior},
+		Timeout: timeout,
+	})
+}
+
+func TestPlugin(t *testing.T) {
+	plugin := newHelperPlugin(t, "browser", "")
+	if plugin.Name() != "corp" {
+		t.Errorf("expected corp, got %s", plugin.Name())
+	}
+	available, err := plugin.IsAvailable()
+	if !available || err != nil {
+		t.Fatalf("expected plugin to be available, got %v, %v", available, err)
+	}
+
+	profiles, err := plugin.Profiles()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expectedProfiles := []api.Profile{
+		{ID: "work", Name: "Work", Path: "/profiles/work", Default: true, LastUsed: time.Date(2025, 4, 28, 7, 52, 14, 0, time.UTC)},
+	}
+	if diff := cmp.Diff(expectedProfiles, profiles); diff != "" {
+		t.Errorf("profiles mismatch (-want +got):\n%s", diff)
+	}
+
+	bookmarks, err := plugin.Bookmarks("work")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expectedBookmarks := []api.BookMark{
+		{Name: "Intranet", URL: "https://intranet.example.com", Folder: []string{"work"}},
+	}
+	if diff := cmp.Diff(expectedBookmarks, bookmarks); diff != "" {
+		t.Errorf("bookmarks mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err = plugin.SearchEngineQueries("work", api.SearchEngineOptions{})
+	if expected := "plugin corp: method search_engine_queries not supported"; err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+}
+
+func TestPluginRequest(t *testing.T) {
+	plugin := newHelperPlugin(t, "echo", "")
+	request := Request{
+		Method:  MethodSearchEngineQueries,
+		Profile: "work",
+		Options: api.SearchEngineOptions{
+			StartTime: time.Date(2025, 4, 28, 0, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2025, 4, 29, 0, 0, 0, 0, time.UTC),
+			Limit:     10,
+		},
+	}
+	var received map[string]any
+	if err := plugin.call(request, &received); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := map[string]any{
+		"method":  "search_engine_queries",
+		"profile": "work",
+		"options": map[string]any{
+			"start_time": "2025-04-28T00:00:00Z",
+			"end_time":   "2025-04-29T00:00:00Z",
+			"limit":      float64(10),
+		},
+	}
+	if diff := cmp.Diff(expected, received); diff != "" {
+		t.Errorf("request mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPluginErrors(t *testing.T) {
+	for _, tt := range []struct {
+		behavior      string
+		timeout       string
+		expectedError string
+	}{
+		{
+			behavior:      "sleep",
+			timeout:       "200ms",
+			expectedError: "plugin corp: profiles timed out after 200ms",
+		},
+		{
+			behavior:      "crash",
+			expectedError: "plugin corp: profiles failed: exit status 3: segmentation fault",
+		},
+		{
+			behavior:      "garbage",
+			expectedError: "plugin corp: profiles returned an invalid response: ",
+		},
+	} {
+		t.Run(tt.behavior, func(t *testing.T) {
+			plugin := newHelperPlugin(t, tt.behavior, tt.timeout)
+			start := time.Now()
+			_, err := plugin.Profiles()
+			if err == nil || !strings.HasPrefix(err.Error(), tt.expectedError) {
+				t.Errorf("expected error %q, got %v", tt.expectedError, err)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("expected the plugin to be stopped, took %s", elapsed)
+			}
+			if available, _ := plugin.IsAvailable(); available {
+				t.Error("expected the plugin not to be available")
+			}
+		})
+	}
+}
+
+func TestPluginCache(t *testing.T) {
+	calls := filepath.Join(t.TempDir(), "calls")
+	t.Setenv("PLUGIN_CALLS_FILE", calls)
+	plugin := newHelperPlugin(t, "browser", "")
+	for range 2 {
+		if available, err := plugin.IsAvailable(); !available || err != nil {
+			t.Fatalf("expected plugin to be available, got %v, %v", available, err)
+		}
+		if _, err := plugin.Profiles(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if _, err := plugin.Bookmarks("work"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	data, err := os.ReadFile(calls)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []string{MethodIsAvailable, MethodProfiles, MethodBookmarks, MethodBookmarks}
+	if diff := cmp.Diff(expected, strings.Fields(string(data))); diff != "" {
+		t.Errorf("calls mismatch (-want +got):\n%s", diff)
+	}
+
+	// the plugin is called again once the results have expired
+	plugin = newHelperPlugin(t, "browser", "")
+	plugin.cacheTTL = 0
+	os.Remove(calls)
+	for range 2 {
+		if _, err := plugin.Profiles(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	data, err = os.ReadFile(calls)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected = []string{MethodProfiles, MethodProfiles}
+	if diff := cmp.Diff(expected, strings.Fields(string(data))); diff != "" {
+		t.Errorf("calls mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPluginCacheErrors(t *testing.T) {
+	calls := filepath.Join(t.TempDir(), "calls")
+	t.Setenv("PLUGIN_CALLS_FILE", calls)
+	plugin := newHelperPlugin(t, "crash", "")
+	for range 2 {
+		if _, err := plugin.Profiles(); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+	data, err := os.ReadFile(calls)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if diff := cmp.Diff([]string{MethodProfiles}, strings.Fields(string(data))); diff != "" {
+		t.Errorf("calls mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHangingPlugin(t *testing.T) {
+	plugin := newHelperPlugin(t, "sleep", "200ms")
+	plugin.cooldown = 500 * time.Millisecond
+	if available, err := plugin.IsAvailable(); available || err == nil {
+		t.Fatalf("expected the plugin not to be available, got %v, %v", available, err)
+	}
+
+	// the plugin is disabled after the timeout, and no longer delays the calls
+	start := time.Now()
+	_, err := plugin.Profiles()
+	if expected := "plugin corp: is_available timed out after 200ms (plugin disabled)"; err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+	if _, err = plugin.Bookmarks("work"); err == nil {
+		t.Error("expected an error")
+	}
+	if available, _ := plugin.IsAvailable(); available {
+		t.Error("expected the plugin not to be available")
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("expected the disabled plugin not to be started, took %s", elapsed)
+	}
+
+	// the plugin is started again after the cooldown
+	time.Sleep(plugin.cooldown)
+	_, err = plugin.Bookmarks("work")
+	if expected := "plugin corp: bookmarks timed out after 200ms"; err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+}
@@ -0,0 +1,71 @@
+package files
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+// ListBookmarks returns the quickmarks and bookmarks of a qutebrowser config directory.
+// Quickmarks are returned in the "quickmarks" folder, and bookmarks in the "bookmarks" folder.
+func ListBookmarks(configPath string) ([]api.BookMark, error) {
+	result := []api.BookMark{}
+
+	// each line of the quickmarks file is "<name> <url>", the name can contain spaces
+	quickmarks, err := readLines(filepath.Join(configPath, "quickmarks"))
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range quickmarks {
+		i := strings.LastIndexAny(line, " \t")
+		if i < 0 {
+			continue
+		}
+		result = append(result, api.BookMark{
+			Name:   strings.TrimSpace(line[:i]),
+			URL:    line[i+1:],
+			Folder: []string{"quickmarks"},
+		})
+	}
+
+	// each line of the bookmarks file is "<url> <title>", the title being optional
+	bookmarks, err := readLines(filepath.Join(configPath, "bookmarks", "urls"))
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range bookmarks {
+		url, title, _ := strings.Cut(line, " ")
+		result = append(result, api.BookMark{
+			Name:   strings.TrimSpace(title),
+			URL:    url,
+			Folder: []string{"bookmarks"},
+		})
+	}
+	return result, nil
+}
+
+// readLines returns the non-empty lines of a file, or no line if the file does not exist
+func readLines(path string) ([]string, error) {
+	data, err := system.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,49 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListBookmarks(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	basePath := filepath.Join(os.Getenv("HOME"), ".config", "qutebrowser")
+	system.WriteFile(filepath.Join(basePath, "quickmarks"), []byte(`gh https://github.com/
+qute docs https://qutebrowser.org/doc/
+
+`), 0644)
+	system.WriteFile(filepath.Join(basePath, "bookmarks", "urls"), []byte(`https://www.redhat.com Red Hat - We make open source technologies for the enterprise
+https://example.com
+`), 0644)
+
+	bookmarks, err := ListBookmarks(basePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []api.BookMark{
+		{Name: "gh", URL: "https://github.com/", Folder: []string{"quickmarks"}},
+		{Name: "qute docs", URL: "https://qutebrowser.org/doc/", Folder: []string{"quickmarks"}},
+		{Name: "Red Hat - We make open source technologies for the enterprise", URL: "https://www.redhat.com", Folder: []string{"bookmarks"}},
+		{Name: "", URL: "https://example.com", Folder: []string{"bookmarks"}},
+	}
+	if diff := cmp.Diff(expected, bookmarks); diff != "" {
+		t.Errorf("Bookmarks differ:\n%s", diff)
+	}
+}
+
+func TestListBookmarksNoFile(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	bookmarks, err := ListBookmarks(filepath.Join(os.Getenv("HOME"), ".config", "qutebrowser"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(bookmarks) != 0 {
+		t.Errorf("Expected no bookmark, got %v", bookmarks)
+	}
+}
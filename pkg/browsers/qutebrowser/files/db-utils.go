@@ -0,0 +1,20 @@
+package files
+
+import (
+	"database/sql"
+	"path/filepath"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+func fromDbDate(dbDate int64) time.Time {
+	return time.Unix(dbDate, 0)
+}
+func toDbDate(d time.Time) int64 {
+	return d.Unix()
+}
+
+func getDb(dataPath string) (*sql.DB, error) {
+	return system.OpenDatabase(filepath.Join(dataPath, "history.sqlite"))
+}
@@ -0,0 +1,69 @@
+package files
+
+import (
+	"net/url"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/charmbracelet/log"
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+)
+
+func SearchEngineQueries(dataPath string, options api.SearchEngineOptions) ([]api.SearchEngineQuery, error) {
+	log.Debug("searching engine queries", "dataPath", dataPath, "options", options)
+
+	type queryResult struct {
+		VisitTime int64
+		URL       string
+	}
+
+	db, err := getDb(dataPath)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	startTime := toDbDate(options.StartTime)
+	endTime := toDbDate(options.EndTime)
+	rows, err := db.Query(`SELECT
+	atime,
+	url
+FROM History
+WHERE
+	url like 'https://www.google.com/search%'
+	AND NOT redirect
+	AND atime >= ?
+	AND atime < ?
+	ORDER BY atime ASC
+LIMIT ?`, startTime, endTime, options.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var searchEngineQueries []api.SearchEngineQuery
+	for rows.Next() {
+		var queryResult queryResult
+		err = rows.Scan(&queryResult.VisitTime, &queryResult.URL)
+		if err != nil {
+			return nil, err
+		}
+		urlParts, err := url.Parse(queryResult.URL)
+		if err != nil {
+			return nil, err
+		}
+		query := urlParts.Query().Get("q")
+
+		searchEngineQueries = append(searchEngineQueries, api.SearchEngineQuery{
+			Query:        query,
+			Date:         fromDbDate(queryResult.VisitTime),
+			SearchEngine: "Google",
+		})
+	}
+	return searchEngineQueries, nil
+}
+
+// ListVisitedPagesFromSearchEngineQuery returns no page, as qutebrowser does not save referrers in its history
+func ListVisitedPagesFromSearchEngineQuery(dataPath string, options api.ListVisitedPagesFromSearchEngineQueryOptions) ([]api.VisitedPageFromSearchEngineQuery, error) {
+	return []api.VisitedPageFromSearchEngineQuery{}, nil
+}
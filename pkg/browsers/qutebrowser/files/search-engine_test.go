@@ -0,0 +1,66 @@
+package files
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+// historyDb is the history.sqlite database of qutebrowser
+var historyDb = globaltest.Fixture{
+	Path: filepath.Join("/home/me/.local/share/qutebrowser", "history.sqlite"),
+	Schema: []string{
+		`CREATE TABLE History (url TEXT NOT NULL, title TEXT NOT NULL, atime INTEGER NOT NULL, redirect BOOLEAN NOT NULL)`,
+	},
+}
+
+func TestSearchEngineQueries(t *testing.T) {
+	// 2025-04-28T07:52:14Z, 2025-04-28T07:52:15Z, 2025-04-29T07:52:14Z
+	globaltest.MemFileSystem(t)
+	dataPath := historyDb.Write(t,
+		`INSERT INTO History (url, title, atime, redirect) VALUES
+			('https://www.google.com/search?q=qutebrowser+quickmarks', 'qutebrowser quickmarks - Google Search', 1745826734, 0),
+			('https://www.google.com/search?q=redirected', '', 1745826735, 1),
+			('https://www.google.com/search?q=tomorrow', 'tomorrow - Google Search', 1745913134, 0)`,
+	)
+	startTime := globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T00:00:00Z"))
+
+	queries, err := SearchEngineQueries(dataPath, api.SearchEngineOptions{StartTime: startTime, EndTime: startTime.AddDate(0, 0, 1), Limit: 10})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []api.SearchEngineQuery{
+		{Query: "qutebrowser quickmarks", Date: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:52:14Z")), SearchEngine: "Google"},
+	}
+	if diff := cmp.Diff(expected, queries, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("Queries differ:\n%s", diff)
+	}
+}
+
+func TestListVisitedPagesFromSourceRepos(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	dataPath := historyDb.Write(t,
+		`INSERT INTO History (url, title, atime, redirect) VALUES
+			('https://github.com/qutebrowser/qutebrowser/pull/8000', 'PR 8000', 1745826734, 0),
+			('https://github.com/qutebrowser/qutebrowser/pull/8000#issuecomment-1', 'PR 8000', 1745826735, 0),
+			('https://github.com/qutebrowser', 'qutebrowser', 1745826736, 0)`,
+	)
+	startTime := globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T00:00:00Z"))
+
+	visits, err := ListVisitedPagesFromSourceRepos(dataPath, api.ListVisitedPagesFromSourceReposOptions{StartTime: startTime, EndTime: startTime.AddDate(0, 0, 1)})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	number := "8000"
+	expected := []api.VisitedPageFromSourceRepos{
+		{Times: 2, Provider: "github", URL: "https://github.com/qutebrowser/qutebrowser/pull/8000", Organization: "qutebrowser", Repository: "qutebrowser", Type: api.SourceRepoPageTypePullRequest, Number: &number},
+		{Times: 1, Provider: "github", URL: "https://github.com/qutebrowser", Organization: "qutebrowser", Repository: "", Type: api.SourceRepoPageTypeOrganizationHome},
+	}
+	if diff := cmp.Diff(expected, visits); diff != "" {
+		t.Errorf("Visits differ:\n%s", diff)
+	}
+}
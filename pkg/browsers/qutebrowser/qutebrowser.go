@@ -0,0 +1,179 @@
+package qutebrowser
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/qutebrowser/files"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+// name of the single profile of an install
+const defaultProfile = "Default"
+
+const installFlatpak = "flatpak"
+
+var instance api.Browser = &Qutebrowser{}
+
+type Qutebrowser struct{}
+
+func (o *Qutebrowser) Name() string {
+	return "qutebrowser"
+}
+
+func (o *Qutebrowser) IsAvailable() (bool, error) {
+	_, err := o.Profiles()
+	return err == nil, err
+}
+
+func (o *Qutebrowser) Bookmarks(profileName string) ([]api.BookMark, error) {
+	profile, err := o.profile(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.ListBookmarks(profile.configPath)
+}
+
+func (o *Qutebrowser) SearchEngineQueries(profileName string, options api.SearchEngineOptions) ([]api.SearchEngineQuery, error) {
+	profile, err := o.profile(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.SearchEngineQueries(profile.dataPath, options)
+}
+
+func (o *Qutebrowser) ListVisitedPagesFromSearchEngineQuery(profileName string, options api.ListVisitedPagesFromSearchEngineQueryOptions) ([]api.VisitedPageFromSearchEngineQuery, error) {
+	profile, err := o.profile(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.ListVisitedPagesFromSearchEngineQuery(profile.dataPath, options)
+}
+
+func (o *Qutebrowser) ListVisitedPagesFromSourceRepos(profileName string, options api.ListVisitedPagesFromSourceReposOptions) ([]api.VisitedPageFromSourceRepos, error) {
+	profile, err := o.profile(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.ListVisitedPagesFromSourceRepos(profile.dataPath, options)
+}
+
+// install is a location where qutebrowser stores its profile
+type install struct {
+	// name is empty for the native install
+	name string
+	// dataPath is the directory containing the history
+	dataPath string
+	// configPath is the directory containing the quickmarks and bookmarks
+	configPath string
+}
+
+func installs() []install {
+	// configured directories are base directories, as passed to the --basedir option
+	if dirs := system.ConfiguredDataDirectories(instance.Name()); len(dirs) > 0 {
+		var result []install
+		for _, dir := range dirs {
+			result = append(result, install{
+				name:       dir.Name,
+				dataPath:   filepath.Join(dir.Path, "data"),
+				configPath: filepath.Join(dir.Path, "config"),
+			})
+		}
+		return result
+	}
+	if system.Os == "darwin" {
+		return []install{
+			{
+				dataPath:   system.HomePath("Library", "Application Support", "qutebrowser"),
+				configPath: system.HomePath(".qutebrowser"),
+			},
+		}
+	}
+	if system.Os == "windows" {
+		return []install{
+			{
+				dataPath:   system.AppDataPath("qutebrowser", "data"),
+				configPath: system.AppDataPath("qutebrowser", "config"),
+			},
+		}
+	}
+	if system.Os == "linux" {
+		return []install{
+			{
+				dataPath:   system.HomePath(".local", "share", "qutebrowser"),
+				configPath: system.HomePath(".config", "qutebrowser"),
+			},
+			{
+				name:       installFlatpak,
+				dataPath:   system.HomePath(".var", "app", "org.qutebrowser.qutebrowser", "data", "qutebrowser"),
+				configPath: system.HomePath(".var", "app", "org.qutebrowser.qutebrowser", "config", "qutebrowser"),
+			},
+		}
+	}
+	return nil
+}
+
+// Profiles returns the profiles of all the installs of the browser.
+// The ID of the profile of the Flatpak install is suffixed with the name of the install.
+// The path of a profile is its data directory.
+func (o *Qutebrowser) Profiles() ([]api.Profile, error) {
+	var result []api.Profile
+	lastErr := fmt.Errorf("browser %q is not supported on %s", o.Name(), system.Os)
+	for _, install := range installs() {
+		_, err := system.FileSystem.Stat(filepath.Join(install.dataPath, "history.sqlite"))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				log.Debug("browser install not found", "browser", o.Name(), "install", install.name, "path", install.dataPath)
+			} else {
+				log.Warn("unable to read browser install", "browser", o.Name(), "install", install.name, "path", install.dataPath, "error", err)
+			}
+			lastErr = err
+			continue
+		}
+		p := api.Profile{
+			ID:      defaultProfile,
+			Name:    defaultProfile,
+			Path:    install.dataPath,
+			Default: install.name == "",
+			Install: install.name,
+		}
+		history := filepath.Join(install.dataPath, "history.sqlite")
+		p.LastUsed = system.LastModified(history, history+"-wal")
+		if install.name != "" {
+			p.ID = fmt.Sprintf("%s (%s)", defaultProfile, install.name)
+		}
+		result = append(result, p)
+	}
+	if len(result) == 0 {
+		return nil, lastErr
+	}
+	return result, nil
+}
+
+// profile returns the install of the profile
+func (o *Qutebrowser) profile(profileName string) (*install, error) {
+	profiles, err := o.Profiles()
+	if err != nil {
+		return nil, err
+	}
+	for _, profile := range profiles {
+		if profile.ID != profileName {
+			continue
+		}
+		for _, install := range installs() {
+			if install.name == profile.Install {
+				return &install, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("profile %s not found", profileName)
+}
+
+func init() {
+	browsers.Register(instance)
+}
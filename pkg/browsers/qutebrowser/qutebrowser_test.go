@@ -0,0 +1,62 @@
+package qutebrowser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/test"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProfiles(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "linux"
+	home := os.Getenv("HOME")
+
+	qutebrowser := &Qutebrowser{}
+	if available, _ := qutebrowser.IsAvailable(); available {
+		t.Errorf("expected qutebrowser not to be available")
+	}
+
+	system.WriteFile(filepath.Join(home, ".local", "share", "qutebrowser", "history.sqlite"), []byte{}, 0644)
+	system.WriteFile(filepath.Join(home, ".var", "app", "org.qutebrowser.qutebrowser", "data", "qutebrowser", "history.sqlite"), []byte{}, 0644)
+	if available, _ := qutebrowser.IsAvailable(); !available {
+		t.Errorf("expected qutebrowser to be available")
+	}
+	profiles, err := qutebrowser.Profiles()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := []string{"Default", "Default (flatpak)"}; !cmp.Equal(expected, test.ProfileIDs(profiles)) {
+		t.Errorf("expected %v, got %v", expected, test.ProfileIDs(profiles))
+	}
+	install, err := qutebrowser.profile("Default (flatpak)")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := filepath.Join(home, ".var", "app", "org.qutebrowser.qutebrowser", "data", "qutebrowser"); install.dataPath != expected {
+		t.Errorf("expected %s, got %s", expected, install.dataPath)
+	}
+	if expected := filepath.Join(home, ".var", "app", "org.qutebrowser.qutebrowser", "config", "qutebrowser"); install.configPath != expected {
+		t.Errorf("expected %s, got %s", expected, install.configPath)
+	}
+	if _, err := qutebrowser.profile("unknown"); err == nil {
+		t.Errorf("expected an error for an unknown profile")
+	}
+
+	system.Os = "darwin"
+	if available, _ := qutebrowser.IsAvailable(); available {
+		t.Errorf("expected qutebrowser not to be available on darwin")
+	}
+	system.WriteFile(filepath.Join(home, "Library", "Application Support", "qutebrowser", "history.sqlite"), []byte{}, 0644)
+	install, err = qutebrowser.profile("Default")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := filepath.Join(home, ".qutebrowser"); install.configPath != expected {
+		t.Errorf("expected %s, got %s", expected, install.configPath)
+	}
+}
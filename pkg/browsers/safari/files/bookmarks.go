@@ -1,13 +1,13 @@
 package files
 
 import (
-	"os"
 	"path/filepath"
 	"time"
 
 	"howett.net/plist"
 
 	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
 )
 
 type Bookmark struct {
@@ -23,9 +23,10 @@ type URIDictionary struct {
 	Title string `plist:"title"`
 }
 
-func ListBookmarks() ([]api.BookMark, error) {
-	path := filepath.Join(os.Getenv("HOME"), "Library", "Safari", "Bookmarks.plist")
-	file, err := os.Open(path)
+// ListBookmarks returns the bookmarks stored in the Bookmarks.plist file of a profile directory.
+func ListBookmarks(profilePath string) ([]api.BookMark, error) {
+	path := filepath.Join(profilePath, "Bookmarks.plist")
+	file, err := system.FileSystem.Open(path)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,80 @@
+package files
+
+import (
+	"database/sql"
+
+	"github.com/charmbracelet/log"
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+)
+
+// ListSyncedTabs returns the tabs synced through iCloud from the other devices of the user,
+// from the `cloud_tab_devices` and `cloud_tabs` tables of a `CloudTabs.db` database.
+func ListSyncedTabs(filename string) ([]api.SyncedDevice, error) {
+	log.Debug("synced tabs", "filename", filename)
+
+	db, err := getDb(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	// the modification date of the devices is not recorded by the oldest versions
+	lastModified := "0"
+	var found int
+	err = db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('cloud_tab_devices') WHERE name = 'last_modified'`).Scan(&found)
+	if err != nil {
+		return nil, err
+	}
+	if found > 0 {
+		lastModified = "cloud_tab_devices.last_modified"
+	}
+
+	rows, err := db.Query(`SELECT
+    cloud_tab_devices.device_uuid,
+    cloud_tab_devices.device_name,
+    ` + lastModified + `,
+    cloud_tabs.title,
+    cloud_tabs.url,
+    cloud_tabs.is_pinned
+  FROM cloud_tab_devices
+  LEFT JOIN cloud_tabs ON cloud_tabs.device_uuid = cloud_tab_devices.device_uuid
+  ORDER BY 3 DESC, cloud_tab_devices.device_uuid, cloud_tabs.rowid`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []api.SyncedDevice{}
+	var currentUUID string
+	for rows.Next() {
+		var uuid string
+		var name, title, url sql.NullString
+		var modified float64
+		var pinned sql.NullBool
+		if err = rows.Scan(&uuid, &name, &modified, &title, &url, &pinned); err != nil {
+			return nil, err
+		}
+		if uuid != currentUUID {
+			currentUUID = uuid
+			device := api.SyncedDevice{
+				Name: name.String,
+				Tabs: []api.SyncedTab{},
+			}
+			if modified > 0 {
+				device.LastModified = fromDbDate(modified)
+			}
+			result = append(result, device)
+		}
+		if !url.Valid {
+			// device without tabs
+			continue
+		}
+		last := &result[len(result)-1]
+		last.Tabs = append(last.Tabs, api.SyncedTab{
+			Title:  title.String,
+			URL:    url.String,
+			Pinned: pinned.Bool,
+		})
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,90 @@
+package files
+
+import (
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+// cloudTabsDb is the CloudTabs.db database of Safari
+var cloudTabsDb = globaltest.Fixture{
+	Path: "/Users/me/Library/Containers/com.apple.Safari/Data/Library/Safari/CloudTabs.db",
+	Schema: []string{
+		`CREATE TABLE cloud_tabs (tab_uuid TEXT PRIMARY KEY NOT NULL, system_fields BLOB NOT NULL, device_uuid TEXT NOT NULL, position BLOB NOT NULL, title TEXT, url TEXT NOT NULL, is_showing_reader BOOLEAN DEFAULT 0, is_pinned BOOLEAN DEFAULT 0, reader_scroll_position_page_index INTEGER, scene_id TEXT)`,
+	},
+}
+
+func TestListSyncedTabs(t *testing.T) {
+	tabs := `INSERT INTO cloud_tabs (tab_uuid, system_fields, device_uuid, position, title, url, is_pinned) VALUES
+		('t1', x'', 'iphone', x'', 'Lisbon trams', 'https://trams.example.com/lisbon', 0),
+		('t2', x'', 'ipad', x'', 'Recipes', 'https://recipes.example.com/', 1),
+		('t3', x'', 'iphone', x'', NULL, 'https://news.example.com/', 0)`
+
+	t.Run("devices with their modification date", func(t *testing.T) {
+		globaltest.MemFileSystem(t)
+		cloudTabsDb.Write(t,
+			`CREATE TABLE cloud_tab_devices (device_uuid TEXT PRIMARY KEY NOT NULL, system_fields BLOB NOT NULL, device_name TEXT, has_duplicate_device_name BOOLEAN DEFAULT 0, is_ephemeral_device BOOLEAN DEFAULT 0, last_modified REAL NOT NULL DEFAULT 0)`,
+			// 2025-04-28T07:52:14Z, 2025-04-29T07:52:14Z
+			`INSERT INTO cloud_tab_devices (device_uuid, system_fields, device_name, last_modified) VALUES
+				('ipad', x'', 'iPad', 767519534),
+				('iphone', x'', 'iPhone', 767605934),
+				('mac', x'', 'MacBook Air', 767519534)`,
+			tabs,
+		)
+		devices, err := ListSyncedTabs(cloudTabsDb.Path)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		expected := []api.SyncedDevice{
+			{
+				Name:         "iPhone",
+				LastModified: time.Date(2025, 4, 29, 7, 52, 14, 0, time.UTC),
+				Tabs: []api.SyncedTab{
+					{Title: "Lisbon trams", URL: "https://trams.example.com/lisbon"},
+					{URL: "https://news.example.com/"},
+				},
+			},
+			{
+				Name:         "iPad",
+				LastModified: time.Date(2025, 4, 28, 7, 52, 14, 0, time.UTC),
+				Tabs:         []api.SyncedTab{{Title: "Recipes", URL: "https://recipes.example.com/", Pinned: true}},
+			},
+			{
+				Name:         "MacBook Air",
+				LastModified: time.Date(2025, 4, 28, 7, 52, 14, 0, time.UTC),
+				Tabs:         []api.SyncedTab{},
+			},
+		}
+		if diff := cmp.Diff(expected, devices); diff != "" {
+			t.Errorf("devices mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("devices without modification date", func(t *testing.T) {
+		globaltest.MemFileSystem(t)
+		cloudTabsDb.Write(t,
+			`CREATE TABLE cloud_tab_devices (device_uuid TEXT PRIMARY KEY NOT NULL, system_fields BLOB NOT NULL, device_name TEXT)`,
+			`INSERT INTO cloud_tab_devices (device_uuid, system_fields, device_name) VALUES ('iphone', x'', 'iPhone')`,
+			tabs,
+		)
+		devices, err := ListSyncedTabs(cloudTabsDb.Path)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		expected := []api.SyncedDevice{
+			{
+				Name: "iPhone",
+				Tabs: []api.SyncedTab{
+					{Title: "Lisbon trams", URL: "https://trams.example.com/lisbon"},
+					{URL: "https://news.example.com/"},
+				},
+			},
+		}
+		if diff := cmp.Diff(expected, devices); diff != "" {
+			t.Errorf("devices mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -2,8 +2,9 @@ package files
 
 import (
 	"database/sql"
-	"fmt"
 	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
 )
 
 var (
@@ -11,7 +12,7 @@ var (
 )
 
 func getDb(path string) (*sql.DB, error) {
-	return sql.Open("sqlite", fmt.Sprintf("file:%s?immutable=1", path))
+	return system.OpenDatabase(path)
 }
 
 func toDbDate(d time.Time) float64 {
@@ -21,3 +22,32 @@ func toDbDate(d time.Time) float64 {
 func fromDbDate(dbDate float64) time.Time {
 	return time.Unix(int64(dbDate)+CoreDataOrigin, 0)
 }
+
+// historyQueries are the queries run on the History.db database, adapted to the version of its schema
+type historyQueries struct {
+	searchEngineQueries         string
+	visitedPagesFromSourceRepos string
+}
+
+// historySchema lists the versions of the History.db database supported, declared in its `metadata` table
+var historySchema = system.Schema[historyQueries]{
+	Database:     "safari History.db",
+	VersionQuery: `SELECT value FROM metadata WHERE key = 'version'`,
+	Ranges: []system.SchemaRange[historyQueries]{
+		{
+			// no version is excluded: a version missing a table or a column read by the queries
+			// is reported as unsupported when querying it, by SchemaDatabase.Err
+			MinVersion: 1,
+			Queries: historyQueries{
+				searchEngineQueries:         searchEngineQueriesSQL,
+				visitedPagesFromSourceRepos: visitedPagesFromSourceReposSQL,
+			},
+		},
+	},
+	// MaxTestedVersion is the version of the History.db fixture of the tests
+	MaxTestedVersion: 20,
+}
+
+func getHistoryDb(path string) (*system.SchemaDatabase[historyQueries], error) {
+	return historySchema.Open(path)
+}
@@ -0,0 +1,88 @@
+package files
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/afero"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+// Profile is an additional profile created with Safari 17+
+type Profile struct {
+	UUID string
+	Name string
+	// Path is the directory containing the History.db file of the profile
+	Path string
+}
+
+// ListProfiles returns the additional profiles stored in the `Profiles` directory of the Safari container directory.
+// The names of the profiles are read from SafariTabs.db, the UUID is used as name when the name cannot be found.
+func ListProfiles(containerPath string) ([]Profile, error) {
+	entries, err := afero.ReadDir(system.FileSystem, filepath.Join(containerPath, "Profiles"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := readProfilesNames(containerPath)
+	if err != nil {
+		log.Warn("unable to read names of Safari profiles", "error", err)
+	}
+
+	var profiles []Profile
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(containerPath, "Profiles", entry.Name())
+		if _, err := system.FileSystem.Stat(filepath.Join(path, "History.db")); err != nil {
+			continue
+		}
+		name := names[entry.Name()]
+		if name == "" {
+			name = entry.Name()
+		}
+		profiles = append(profiles, Profile{
+			UUID: entry.Name(),
+			Name: name,
+			Path: path,
+		})
+	}
+	return profiles, nil
+}
+
+// readProfilesNames returns the names of the profiles, indexed by their UUIDs.
+// Profiles are stored in SafariTabs.db as bookmarks folders with the subtype 2.
+func readProfilesNames(containerPath string) (map[string]string, error) {
+	path := filepath.Join(containerPath, "SafariTabs.db")
+	if _, err := system.FileSystem.Stat(path); err != nil {
+		return nil, err
+	}
+	db, err := getDb(path)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT external_uuid, title FROM bookmarks WHERE subtype = 2 AND external_uuid IS NOT NULL`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := map[string]string{}
+	for rows.Next() {
+		var uuid, title string
+		if err = rows.Scan(&uuid, &title); err != nil {
+			return nil, err
+		}
+		names[uuid] = title
+	}
+	return names, nil
+}
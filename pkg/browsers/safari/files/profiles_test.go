@@ -0,0 +1,56 @@
+package files
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListProfiles(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	containerPath := "/Users/me/Library/Containers/com.apple.Safari/Data/Library/Safari"
+
+	profiles, err := ListProfiles(containerPath)
+	if err != nil {
+		t.Fatalf("Expected no error without Profiles directory, got %v", err)
+	}
+	if len(profiles) != 0 {
+		t.Fatalf("Expected no profile, got %v", profiles)
+	}
+
+	for _, uuid := range []string{"2A5B3C9E-0D1F-4E8A-9B7C-6D5E4F3A2B1C", "8F7E6D5C-4B3A-4291-8F7E-6D5C4B3A2918"} {
+		system.WriteFile(filepath.Join(containerPath, "Profiles", uuid, "History.db"), []byte{}, 0644)
+	}
+	// directory without history is ignored
+	system.FileSystem.MkdirAll(filepath.Join(containerPath, "Profiles", "empty"), 0755)
+
+	globaltest.WriteDatabase(t, filepath.Join(containerPath, "SafariTabs.db"),
+		`CREATE TABLE bookmarks (id INTEGER PRIMARY KEY, parent INTEGER, type INTEGER, subtype INTEGER, title TEXT, external_uuid TEXT)`,
+		`INSERT INTO bookmarks (parent, type, subtype, title, external_uuid) VALUES
+			(0, 1, 2, 'Work', '2A5B3C9E-0D1F-4E8A-9B7C-6D5E4F3A2B1C'),
+			(0, 1, 0, 'Not a profile', '8F7E6D5C-4B3A-4291-8F7E-6D5C4B3A2918')`,
+	)
+
+	profiles, err = ListProfiles(containerPath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []Profile{
+		{
+			UUID: "2A5B3C9E-0D1F-4E8A-9B7C-6D5E4F3A2B1C",
+			Name: "Work",
+			Path: filepath.Join(containerPath, "Profiles", "2A5B3C9E-0D1F-4E8A-9B7C-6D5E4F3A2B1C"),
+		},
+		{
+			UUID: "8F7E6D5C-4B3A-4291-8F7E-6D5C4B3A2918",
+			Name: "8F7E6D5C-4B3A-4291-8F7E-6D5C4B3A2918",
+			Path: filepath.Join(containerPath, "Profiles", "8F7E6D5C-4B3A-4291-8F7E-6D5C4B3A2918"),
+		},
+	}
+	if diff := cmp.Diff(expected, profiles); diff != "" {
+		t.Errorf("Profiles differ:\n%s", diff)
+	}
+}
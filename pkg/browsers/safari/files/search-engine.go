@@ -2,20 +2,31 @@ package files
 
 import (
 	"net/url"
-	"os"
 	"path/filepath"
 
 	"github.com/feloy/browsers-mcp-server/pkg/api"
 )
 
-func SearchEngineQueries(options api.SearchEngineOptions) ([]api.SearchEngineQuery, error) {
+const searchEngineQueriesSQL = `SELECT DISTINCT
+	round(history_visits.visit_time),
+	history_items.url
+FROM history_visits
+INNER JOIN history_items ON history_items.id = history_visits.history_item
+WHERE 
+	history_items.url like 'https://www.google.com/search%'
+	AND visit_time >= ?
+	AND visit_time < ?
+	ORDER BY visit_time ASC
+LIMIT ?`
+
+func SearchEngineQueries(profilePath string, options api.SearchEngineOptions) ([]api.SearchEngineQuery, error) {
 	type queryResult struct {
 		VisitTime float64
 		URL       string
 	}
 
-	path := filepath.Join(os.Getenv("HOME"), "Library", "Safari", "History.db")
-	db, err := getDb(path)
+	path := filepath.Join(profilePath, "History.db")
+	db, err := getHistoryDb(path)
 	if err != nil {
 		return nil, err
 	}
@@ -23,19 +34,9 @@ func SearchEngineQueries(options api.SearchEngineOptions) ([]api.SearchEngineQue
 
 	startTime := toDbDate(options.StartTime)
 	endTime := toDbDate(options.EndTime)
-	rows, err := db.Query(`SELECT DISTINCT
-	round(history_visits.visit_time),
-	history_items.url
-FROM history_visits
-INNER JOIN history_items ON history_items.id = history_visits.history_item
-WHERE 
-	history_items.url like 'https://www.google.com/search%'
-	AND visit_time >= ?
-	AND visit_time < ?
-	ORDER BY visit_time ASC
-LIMIT ?`, startTime, endTime, options.Limit)
+	rows, err := db.Query(db.Queries.searchEngineQueries, startTime, endTime, options.Limit)
 	if err != nil {
-		return nil, err
+		return nil, db.Err(err)
 	}
 	defer rows.Close()
 
@@ -61,6 +62,6 @@ LIMIT ?`, startTime, endTime, options.Limit)
 	return searchEngineQueries, nil
 }
 
-func ListVisitedPagesFromSearchEngineQuery(options api.ListVisitedPagesFromSearchEngineQueryOptions) ([]api.VisitedPageFromSearchEngineQuery, error) {
+func ListVisitedPagesFromSearchEngineQuery(profilePath string, options api.ListVisitedPagesFromSearchEngineQueryOptions) ([]api.VisitedPageFromSearchEngineQuery, error) {
 	return []api.VisitedPageFromSearchEngineQuery{}, nil
 }
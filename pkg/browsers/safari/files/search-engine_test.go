@@ -0,0 +1,51 @@
+package files
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+// historyDb is the History.db database of a Safari profile
+var historyDb = globaltest.Fixture{
+	Path: filepath.Join("/Users/me/Library/Safari", "History.db"),
+	Schema: []string{
+		`CREATE TABLE metadata (key TEXT NOT NULL UNIQUE, value)`,
+		`INSERT INTO metadata (key, value) VALUES ('version', 20)`,
+		`CREATE TABLE history_items (id INTEGER PRIMARY KEY AUTOINCREMENT, url TEXT NOT NULL UNIQUE, domain_expansion TEXT NULL, visit_count INTEGER NOT NULL DEFAULT 0, visit_count_score INTEGER NOT NULL DEFAULT 0, status_code INTEGER NOT NULL DEFAULT 0)`,
+		`CREATE TABLE history_visits (id INTEGER PRIMARY KEY AUTOINCREMENT, history_item INTEGER NOT NULL REFERENCES history_items(id) ON DELETE CASCADE, visit_time REAL NOT NULL, title TEXT NULL, load_successful BOOLEAN NOT NULL DEFAULT 1, http_non_get BOOLEAN NOT NULL DEFAULT 0, synthesized BOOLEAN NOT NULL DEFAULT 0, redirect_source INTEGER NULL UNIQUE, redirect_destination INTEGER NULL UNIQUE, origin INTEGER NOT NULL DEFAULT 0, generation INTEGER NOT NULL DEFAULT 0, attributes INTEGER NOT NULL DEFAULT 0, score INTEGER NOT NULL DEFAULT 0)`,
+	},
+}
+
+func TestSearchEngineQueries(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	profilePath := historyDb.Write(t,
+		`INSERT INTO history_items (id, url) VALUES
+			(1, 'https://www.google.com/search?q=webkit&client=safari'),
+			(2, 'https://webkit.org/'),
+			(3, 'https://www.google.com/search?q=icloud+tabs')`,
+		// 2025-04-28T07:52:14Z (twice, with the fractions of seconds of Safari), 2025-04-28T07:53:14Z, 2025-04-29T07:52:14Z
+		`INSERT INTO history_visits (id, history_item, visit_time, title, score) VALUES
+			(1, 1, 767519534.123, 'webkit - Google Search', 100),
+			(2, 1, 767519534.25, 'webkit - Google Search', 100),
+			(3, 2, 767519594.5, 'WebKit', 100),
+			(4, 3, 767605934.0, 'icloud tabs - Google Search', 100)`,
+	)
+	startTime := globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T00:00:00Z"))
+	endTime := startTime.AddDate(0, 0, 1)
+
+	queries, err := SearchEngineQueries(profilePath, api.SearchEngineOptions{StartTime: startTime, EndTime: endTime, Limit: 10})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedQueries := []api.SearchEngineQuery{
+		{Query: "webkit", Date: globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T07:52:14Z")), SearchEngine: "Google"},
+	}
+	if diff := cmp.Diff(expectedQueries, queries, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("Queries differ:\n%s", diff)
+	}
+}
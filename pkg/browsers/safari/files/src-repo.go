@@ -1,33 +1,12 @@
 package files
 
 import (
-	"os"
 	"path/filepath"
 
 	"github.com/feloy/browsers-mcp-server/pkg/api"
 )
 
-func ListVisitedPagesFromSourceRepos(options api.ListVisitedPagesFromSourceReposOptions) ([]api.VisitedPageFromSourceRepos, error) {
-	type queryResult struct {
-		Times        int
-		URL          string
-		Organization string
-		Repository   string
-		Pagetype     string
-		Name         string
-	}
-
-	path := filepath.Join(os.Getenv("HOME"), "Library", "Safari", "History.db")
-	db, err := getDb(path)
-	if err != nil {
-		return nil, err
-	}
-	defer db.Close()
-
-	startTime := toDbDate(options.StartTime)
-	endTime := toDbDate(options.EndTime)
-
-	rows, err := db.Query(`with recursive 
+const visitedPagesFromSourceReposSQL = `with recursive 
   cte0 (title, pathAndQuery) as (
     SELECT 
       history_visits.title AS title,
@@ -110,10 +89,32 @@ select count(*) as c, url, organization, repository, pagetype, name from cte6
 where (? = '' OR ? = pagetype) AND pagetype != 'other details'
 group by url, organization, repository, pagetype, name
 order by c desc;
-`, startTime, endTime, options.Type, options.Type)
+`
+
+func ListVisitedPagesFromSourceRepos(profilePath string, options api.ListVisitedPagesFromSourceReposOptions) ([]api.VisitedPageFromSourceRepos, error) {
+	type queryResult struct {
+		Times        int
+		URL          string
+		Organization string
+		Repository   string
+		Pagetype     string
+		Name         string
+	}
+
+	path := filepath.Join(profilePath, "History.db")
+	db, err := getHistoryDb(path)
 	if err != nil {
 		return nil, err
 	}
+	defer db.Close()
+
+	startTime := toDbDate(options.StartTime)
+	endTime := toDbDate(options.EndTime)
+
+	rows, err := db.Query(db.Queries.visitedPagesFromSourceRepos, startTime, endTime, options.Type, options.Type)
+	if err != nil {
+		return nil, db.Err(err)
+	}
 	defer rows.Close()
 
 	var visitedPages []api.VisitedPageFromSourceRepos
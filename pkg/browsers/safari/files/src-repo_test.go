@@ -0,0 +1,58 @@
+package files
+
+import (
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListVisitedPagesFromSourceRepos(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	profilePath := historyDb.Write(t,
+		`INSERT INTO history_items (id, url) VALUES
+			(1, 'https://github.com/WebKit/WebKit'),
+			(2, 'https://github.com/WebKit/WebKit/pulls'),
+			(3, 'https://github.com/WebKit/WebKit/issues/3')`,
+		// visits with a score lower than 100 are redirections, not counted
+		`INSERT INTO history_visits (id, history_item, visit_time, title, score) VALUES
+			(1, 1, 767519534.0, 'WebKit/WebKit', 100),
+			(2, 1, 767519594.0, 'WebKit/WebKit', 100),
+			(3, 1, 767519595.0, 'WebKit/WebKit', 20),
+			(4, 2, 767519596.0, 'Pull requests', 100),
+			(5, 3, 767605934.0, 'Issue 3', 100)`,
+	)
+	startTime := globaltest.Must(time.Parse(time.RFC3339, "2025-04-28T00:00:00Z"))
+	endTime := startTime.AddDate(0, 0, 1)
+
+	for _, tt := range []struct {
+		name     string
+		pageType api.SourceRepoPageType
+		expected []api.VisitedPageFromSourceRepos
+	}{
+		{
+			name: "all types",
+			expected: []api.VisitedPageFromSourceRepos{
+				{Times: 2, Provider: "github", URL: "https://github.com/WebKit/WebKit", Organization: "WebKit", Repository: "WebKit", Type: api.SourceRepoPageTypeRepositoryHome},
+				{Times: 1, Provider: "github", URL: "https://github.com/WebKit/WebKit/pulls", Organization: "WebKit", Repository: "WebKit", Type: api.SourceRepoPageTypePullRequestsList},
+			},
+		},
+		{
+			name:     "issues only",
+			pageType: api.SourceRepoPageTypeIssue,
+			expected: nil,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			visits, err := ListVisitedPagesFromSourceRepos(profilePath, api.ListVisitedPagesFromSourceReposOptions{Type: tt.pageType, StartTime: startTime, EndTime: endTime})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, visits); diff != "" {
+				t.Errorf("Visits differ:\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,12 +1,19 @@
 package safari
 
 import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
 	"github.com/feloy/browsers-mcp-server/pkg/api"
 	"github.com/feloy/browsers-mcp-server/pkg/browsers"
 	"github.com/feloy/browsers-mcp-server/pkg/browsers/safari/files"
 	"github.com/feloy/browsers-mcp-server/pkg/system"
 )
 
+// name of the profile existing before Safari 17 introduced profiles
+const defaultProfile = "DefaultProfile"
+
 var instance api.Browser = &Safari{}
 
 type Safari struct{}
@@ -19,25 +26,113 @@ func (o *Safari) IsAvailable() (bool, error) {
 	return system.Os == "darwin", nil
 }
 
-func (o *Safari) Profiles() ([]string, error) {
-	// TODO support multiple profiles
-	return []string{"DefaultProfile"}, nil
-}
-
 func (o *Safari) Bookmarks(profileName string) ([]api.BookMark, error) {
-	return files.ListBookmarks()
+	if _, err := o.profilePath(profileName); err != nil {
+		return nil, err
+	}
+	// bookmarks are shared by all the profiles
+	return files.ListBookmarks(defaultProfilePath())
 }
 
 func (o *Safari) SearchEngineQueries(profileName string, options api.SearchEngineOptions) ([]api.SearchEngineQuery, error) {
-	return files.SearchEngineQueries(options)
+	profilePath, err := o.profilePath(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.SearchEngineQueries(profilePath, options)
 }
 
 func (o *Safari) ListVisitedPagesFromSearchEngineQuery(profileName string, options api.ListVisitedPagesFromSearchEngineQueryOptions) ([]api.VisitedPageFromSearchEngineQuery, error) {
-	return files.ListVisitedPagesFromSearchEngineQuery(options)
+	profilePath, err := o.profilePath(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.ListVisitedPagesFromSearchEngineQuery(profilePath, options)
 }
 
 func (o *Safari) ListVisitedPagesFromSourceRepos(profileName string, options api.ListVisitedPagesFromSourceReposOptions) ([]api.VisitedPageFromSourceRepos, error) {
-	return files.ListVisitedPagesFromSourceRepos(options)
+	profilePath, err := o.profilePath(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return files.ListVisitedPagesFromSourceRepos(profilePath, options)
+}
+
+var _ api.SyncedTabsReader = &Safari{}
+
+// ListSyncedTabs returns the tabs synced through iCloud, shared by all the profiles.
+// No device is returned when the user does not sync Safari with iCloud.
+func (o *Safari) ListSyncedTabs(profileName string) ([]api.SyncedDevice, error) {
+	if _, err := o.profilePath(profileName); err != nil {
+		return nil, err
+	}
+	// the database is moved to the Safari container by the recent versions
+	for _, dir := range []string{containerPath(), defaultProfilePath()} {
+		filename := filepath.Join(dir, "CloudTabs.db")
+		if _, err := system.FileSystem.Stat(filename); err == nil {
+			return files.ListSyncedTabs(filename)
+		}
+	}
+	return []api.SyncedDevice{}, nil
+}
+
+// defaultProfilePath returns the directory containing the files of the default profile,
+// which can be replaced by the first directory configured for Safari
+func defaultProfilePath() string {
+	if dirs := system.ConfiguredDataDirectories(instance.Name()); len(dirs) > 0 {
+		return dirs[0].Path
+	}
+	return system.HomePath("Library", "Safari")
+}
+
+// containerPath returns the directory of the Safari container, containing the additional profiles.
+// The first directory configured for Safari replaces it too, so that a copy of the default profile
+// and of the container can be read from a single directory.
+func containerPath() string {
+	if dirs := system.ConfiguredDataDirectories(instance.Name()); len(dirs) > 0 {
+		return dirs[0].Path
+	}
+	return system.HomePath("Library", "Containers", "com.apple.Safari", "Data", "Library", "Safari")
+}
+
+// Profiles returns the default profile, followed by the additional profiles created with Safari 17+.
+// The IDs of the additional profiles are their UUIDs.
+// The path of a profile is the directory containing its History.db file.
+func (o *Safari) Profiles() ([]api.Profile, error) {
+	result := []api.Profile{
+		{ID: defaultProfile, Name: defaultProfile, Path: defaultProfilePath(), Default: true},
+	}
+	// the container can be unreadable, e.g. when the server has no Full Disk Access
+	additionalProfiles, err := files.ListProfiles(containerPath())
+	if err != nil {
+		log.Warn("unable to list Safari profiles", "path", containerPath(), "error", err)
+	}
+	for _, additionalProfile := range additionalProfiles {
+		result = append(result, api.Profile{
+			ID:   additionalProfile.UUID,
+			Name: additionalProfile.Name,
+			Path: additionalProfile.Path,
+		})
+	}
+	for i := range result {
+		history := filepath.Join(result[i].Path, "History.db")
+		result[i].LastUsed = system.LastModified(history, history+"-wal")
+	}
+	return result, nil
+}
+
+// profilePath returns the directory containing the History.db file of the profile
+func (o *Safari) profilePath(profileName string) (string, error) {
+	profiles, err := o.Profiles()
+	if err != nil {
+		return "", err
+	}
+	for _, profile := range profiles {
+		if profile.ID == profileName {
+			return profile.Path, nil
+		}
+	}
+	return "", fmt.Errorf("profile %s not found", profileName)
 }
 
 func init() {
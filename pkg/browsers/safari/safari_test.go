@@ -0,0 +1,61 @@
+package safari
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/test"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProfiles(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "darwin"
+
+	safari := &Safari{}
+	profiles, err := safari.Profiles()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := []string{"DefaultProfile"}; !cmp.Equal(expected, test.ProfileIDs(profiles)) {
+		t.Errorf("expected %v, got %v", expected, test.ProfileIDs(profiles))
+	}
+
+	// the Profiles directory cannot be read
+	system.WriteFile(filepath.Join(containerPath(), "Profiles"), []byte{}, 0644)
+	profiles, err = safari.Profiles()
+	if err != nil {
+		t.Fatalf("expected no error when the profiles cannot be listed, got %v", err)
+	}
+	if expected := []string{"DefaultProfile"}; !cmp.Equal(expected, test.ProfileIDs(profiles)) {
+		t.Errorf("expected %v, got %v", expected, test.ProfileIDs(profiles))
+	}
+}
+
+func TestProfilesInConfiguredDirectory(t *testing.T) {
+	globaltest.MemFileSystem(t)
+	system.Os = "darwin"
+	system.DataDirectories = map[string][]string{"safari": {"/data/safari"}}
+	t.Cleanup(func() { system.DataDirectories = nil })
+
+	uuid := "2A5B3C9E-0D1F-4E8A-9B7C-6D5E4F3A2B1C"
+	system.WriteFile(filepath.Join("/data/safari", "Profiles", uuid, "History.db"), []byte{}, 0644)
+
+	safari := &Safari{}
+	profiles, err := safari.Profiles()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := []string{"DefaultProfile", uuid}; !cmp.Equal(expected, test.ProfileIDs(profiles)) {
+		t.Errorf("expected %v, got %v", expected, test.ProfileIDs(profiles))
+	}
+	path, err := safari.profilePath(uuid)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := filepath.Join("/data/safari", "Profiles", uuid); path != expected {
+		t.Errorf("expected %s, got %s", expected, path)
+	}
+}
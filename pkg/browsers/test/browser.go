@@ -8,7 +8,7 @@ type Browser struct {
 	name                                   string
 	available                              bool
 	availableError                         error
-	profiles                               []string
+	profiles                               []api.Profile
 	profilesError                          error
 	bookmarks                              []api.BookMark
 	bookmarksError                         error
@@ -24,7 +24,7 @@ type NewBrowserOptions struct {
 	Name                                   string
 	Available                              bool
 	AvailableError                         error
-	Profiles                               []string
+	Profiles                               []api.Profile
 	ProfilesError                          error
 	Bookmarks                              []api.BookMark
 	BookmarksError                         error
@@ -34,6 +34,39 @@ type NewBrowserOptions struct {
 	VisitedPagesFromSearchEngineQueryError error
 	VisitedPagesFromSourceRepos            []api.VisitedPageFromSourceRepos
 	VisitedPagesFromSourceReposError       error
+	// Notes and NotesError are used by NewNotesBrowser only
+	Notes      []api.Note
+	NotesError error
+	// Collections and CollectionsError are used by NewCollectionsBrowser only
+	Collections      []api.Collection
+	CollectionsError error
+	// OpenTabs and OpenTabsError are used by NewTabsBrowser and NewRecentlyClosedBrowser only
+	OpenTabs      []api.Tab
+	OpenTabsError error
+	// RecentlyClosed and RecentlyClosedError are used by NewRecentlyClosedBrowser only
+	RecentlyClosed      api.RecentlyClosed
+	RecentlyClosedError error
+	// SyncedDevices and SyncedTabsError are used by NewSyncedTabsBrowser only
+	SyncedDevices   []api.SyncedDevice
+	SyncedTabsError error
+}
+
+// Profiles returns profiles whose IDs and names are the given names
+func Profiles(names ...string) []api.Profile {
+	result := make([]api.Profile, 0, len(names))
+	for _, name := range names {
+		result = append(result, api.Profile{ID: name, Name: name})
+	}
+	return result
+}
+
+// ProfileIDs returns the IDs of the profiles
+func ProfileIDs(profiles []api.Profile) []string {
+	result := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		result = append(result, profile.ID)
+	}
+	return result
 }
 
 func NewBrowser(options NewBrowserOptions) *Browser {
@@ -62,7 +95,7 @@ func (o *Browser) IsAvailable() (bool, error) {
 	return o.available, o.availableError
 }
 
-func (o *Browser) Profiles() ([]string, error) {
+func (o *Browser) Profiles() ([]api.Profile, error) {
 	return o.profiles, o.profilesError
 }
 
@@ -81,3 +114,107 @@ func (o *Browser) ListVisitedPagesFromSearchEngineQuery(profile string, options
 func (o *Browser) ListVisitedPagesFromSourceRepos(profile string, options api.ListVisitedPagesFromSourceReposOptions) ([]api.VisitedPageFromSourceRepos, error) {
 	return o.visitedPagesFromSourceRepos, o.visitedPagesFromSourceReposError
 }
+
+var _ api.NotesReader = &NotesBrowser{}
+
+// NotesBrowser is a browser letting users take notes
+type NotesBrowser struct {
+	*Browser
+	notes      []api.Note
+	notesError error
+}
+
+func NewNotesBrowser(options NewBrowserOptions) *NotesBrowser {
+	return &NotesBrowser{
+		Browser:    NewBrowser(options),
+		notes:      options.Notes,
+		notesError: options.NotesError,
+	}
+}
+
+func (o *NotesBrowser) ListNotes(profile string, options api.ListNotesOptions) ([]api.Note, error) {
+	return o.notes, o.notesError
+}
+
+var _ api.CollectionsReader = &CollectionsBrowser{}
+
+// CollectionsBrowser is a browser letting users group saved pages in collections
+type CollectionsBrowser struct {
+	*Browser
+	collections      []api.Collection
+	collectionsError error
+}
+
+func NewCollectionsBrowser(options NewBrowserOptions) *CollectionsBrowser {
+	return &CollectionsBrowser{
+		Browser:          NewBrowser(options),
+		collections:      options.Collections,
+		collectionsError: options.CollectionsError,
+	}
+}
+
+func (o *CollectionsBrowser) ListCollections(profile string, options api.ListCollectionsOptions) ([]api.Collection, error) {
+	return o.collections, o.collectionsError
+}
+
+// TabsBrowser is a browser listing its open tabs
+type TabsBrowser struct {
+	*Browser
+	openTabs      []api.Tab
+	openTabsError error
+	// OpenTabsOptions are the options of the last call to ListOpenTabs
+	OpenTabsOptions api.ListOpenTabsOptions
+}
+
+func NewTabsBrowser(options NewBrowserOptions) *TabsBrowser {
+	return &TabsBrowser{
+		Browser:       NewBrowser(options),
+		openTabs:      options.OpenTabs,
+		openTabsError: options.OpenTabsError,
+	}
+}
+
+func (o *TabsBrowser) ListOpenTabs(profile string, options api.ListOpenTabsOptions) ([]api.Tab, error) {
+	o.OpenTabsOptions = options
+	return o.openTabs, o.openTabsError
+}
+
+// RecentlyClosedBrowser is a browser listing its open tabs, and its recently closed tabs and windows
+type RecentlyClosedBrowser struct {
+	*TabsBrowser
+	recentlyClosed      api.RecentlyClosed
+	recentlyClosedError error
+}
+
+func NewRecentlyClosedBrowser(options NewBrowserOptions) *RecentlyClosedBrowser {
+	return &RecentlyClosedBrowser{
+		TabsBrowser:         NewTabsBrowser(options),
+		recentlyClosed:      options.RecentlyClosed,
+		recentlyClosedError: options.RecentlyClosedError,
+	}
+}
+
+func (o *RecentlyClosedBrowser) ListRecentlyClosed(profile string) (api.RecentlyClosed, error) {
+	return o.recentlyClosed, o.recentlyClosedError
+}
+
+var _ api.SyncedTabsReader = &SyncedTabsBrowser{}
+
+// SyncedTabsBrowser is a browser syncing the tabs of the other devices of the user
+type SyncedTabsBrowser struct {
+	*Browser
+	syncedDevices   []api.SyncedDevice
+	syncedTabsError error
+}
+
+func NewSyncedTabsBrowser(options NewBrowserOptions) *SyncedTabsBrowser {
+	return &SyncedTabsBrowser{
+		Browser:         NewBrowser(options),
+		syncedDevices:   options.SyncedDevices,
+		syncedTabsError: options.SyncedTabsError,
+	}
+}
+
+func (o *SyncedTabsBrowser) ListSyncedTabs(profile string) ([]api.SyncedDevice, error) {
+	return o.syncedDevices, o.syncedTabsError
+}
@@ -1,15 +1,81 @@
 package config
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
 	"github.com/BurntSushi/toml"
 	"github.com/feloy/browsers-mcp-server/pkg/system"
 )
 
+// DefaultProfilePolicy indicates how the profile is chosen when the profile parameter of a tool is not provided
+// and several profiles are available.
+type DefaultProfilePolicy string
+
+const (
+	// DefaultProfilePolicyLastUsed chooses the most recently used profile among the profiles of all the browsers
+	DefaultProfilePolicyLastUsed DefaultProfilePolicy = "last-used"
+	// DefaultProfilePolicyConfig chooses the profile indicated by `default_profile`
+	DefaultProfilePolicyConfig DefaultProfilePolicy = "config"
+	// DefaultProfilePolicyError returns an error, the profile parameter must be provided
+	DefaultProfilePolicyError DefaultProfilePolicy = "error"
+)
+
 // StaticConfig is the configuration for the server.
 // It allows to configure server specific settings and tools to be enabled or disabled.
 type StaticConfig struct {
 	EnabledTools  []string `toml:"enabled_tools,omitempty"`
 	DisabledTools []string `toml:"disabled_tools,omitempty"`
+
+	// DefaultProfilePolicy defaults to `config` when DefaultProfile is set, to `last-used` otherwise
+	DefaultProfilePolicy DefaultProfilePolicy `toml:"default_profile_policy,omitempty"`
+	// DefaultProfile is a value of the profile parameter of the tools (for example "Work on chrome") or an alias
+	DefaultProfile string `toml:"default_profile,omitempty"`
+
+	// Aliases are friendly names designating profiles, indexed by their names
+	Aliases map[string]ProfileAlias `toml:"aliases,omitempty"`
+
+	// DataDirectories replace the default locations of the data of the browsers, indexed by browser name.
+	// Environment variables are expanded.
+	DataDirectories map[string][]string `toml:"data_directories,omitempty"`
+
+	// DevTools are the DevTools endpoints of running browsers ("http://localhost:9222"), indexed by browser name,
+	// used to list the open tabs
+	DevTools map[string]string `toml:"devtools,omitempty"`
+
+	// Plugins are external browser providers, executables speaking the plugin protocol over stdio
+	Plugins []PluginConfig `toml:"plugins,omitempty"`
+}
+
+// DefaultPluginTimeout is the maximum duration of a call to a plugin, when not configured
+const DefaultPluginTimeout = 10 * time.Second
+
+// PluginConfig declares an external browser provider
+type PluginConfig struct {
+	// Name is the name of the browser provided by the plugin
+	Name    string   `toml:"name"`
+	Command string   `toml:"command"`
+	Args    []string `toml:"args,omitempty"`
+	// Timeout is the maximum duration of a call to the plugin ("5s", "1m", ...), defaults to DefaultPluginTimeout
+	Timeout string `toml:"timeout,omitempty"`
+}
+
+// GetTimeout returns the maximum duration of a call to the plugin
+func (c PluginConfig) GetTimeout() time.Duration {
+	timeout, err := time.ParseDuration(c.Timeout)
+	if err != nil || timeout <= 0 {
+		return DefaultPluginTimeout
+	}
+	return timeout
+}
+
+// ProfileAlias designates a profile of a browser
+type ProfileAlias struct {
+	Browser string `toml:"browser"`
+	// Profile is the name or the ID of the profile, and can be omitted if the browser has a single profile
+	Profile string `toml:"profile,omitempty"`
 }
 
 // ReadConfig reads the toml file and returns the StaticConfig.
@@ -24,5 +90,67 @@ func ReadConfig(configPath string) (*StaticConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = config.validate(); err != nil {
+		return nil, err
+	}
 	return config, nil
 }
+
+func (c *StaticConfig) validate() error {
+	switch c.DefaultProfilePolicy {
+	case "", DefaultProfilePolicyLastUsed, DefaultProfilePolicyError:
+	case DefaultProfilePolicyConfig:
+		if c.DefaultProfile == "" {
+			return fmt.Errorf("default_profile must be set with default_profile_policy %q", DefaultProfilePolicyConfig)
+		}
+	default:
+		return fmt.Errorf("invalid default_profile_policy %q, must be one of %q, %q or %q",
+			c.DefaultProfilePolicy, DefaultProfilePolicyLastUsed, DefaultProfilePolicyConfig, DefaultProfilePolicyError)
+	}
+	for name, alias := range c.Aliases {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("alias name cannot be empty")
+		}
+		if alias.Browser == "" {
+			return fmt.Errorf("browser of alias %q must be set", name)
+		}
+	}
+	for browser, endpoint := range c.DevTools {
+		if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid devtools endpoint %q of browser %q, must be an http URL", endpoint, browser)
+		}
+	}
+	names := map[string]bool{}
+	for i, plugin := range c.Plugins {
+		if strings.TrimSpace(plugin.Name) == "" {
+			return fmt.Errorf("name of plugin #%d must be set", i+1)
+		}
+		if strings.Contains(plugin.Name, " on ") {
+			return fmt.Errorf("name of plugin %q cannot contain \" on \"", plugin.Name)
+		}
+		if names[plugin.Name] {
+			return fmt.Errorf("plugin %q is declared several times", plugin.Name)
+		}
+		names[plugin.Name] = true
+		if plugin.Command == "" {
+			return fmt.Errorf("command of plugin %q must be set", plugin.Name)
+		}
+		if plugin.Timeout != "" {
+			if timeout, err := time.ParseDuration(plugin.Timeout); err != nil || timeout <= 0 {
+				return fmt.Errorf("invalid timeout %q of plugin %q", plugin.Timeout, plugin.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// GetDefaultProfilePolicy returns the policy to use when the profile parameter of a tool is not provided
+func (c *StaticConfig) GetDefaultProfilePolicy() DefaultProfilePolicy {
+	if c.DefaultProfilePolicy != "" {
+		return c.DefaultProfilePolicy
+	}
+	if c.DefaultProfile != "" {
+		return DefaultProfilePolicyConfig
+	}
+	return DefaultProfilePolicyLastUsed
+}
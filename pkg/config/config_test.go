@@ -3,8 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestReadConfigMissingFile(t *testing.T) {
@@ -90,3 +92,180 @@ func writeConfig(t *testing.T, content string) string {
 	}
 	return path
 }
+
+func TestReadConfigDefaultProfile(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		content        string
+		expectedPolicy DefaultProfilePolicy
+		expectedError  string
+	}{
+		{
+			name:           "policy defaults to last-used",
+			content:        ``,
+			expectedPolicy: DefaultProfilePolicyLastUsed,
+		},
+		{
+			name:           "policy defaults to config when a default profile is set",
+			content:        `default_profile = "Work on chrome"`,
+			expectedPolicy: DefaultProfilePolicyConfig,
+		},
+		{
+			name:           "explicit policy",
+			content:        `default_profile_policy = "error"`,
+			expectedPolicy: DefaultProfilePolicyError,
+		},
+		{
+			name:          "config policy without default profile",
+			content:       `default_profile_policy = "config"`,
+			expectedError: `default_profile must be set with default_profile_policy "config"`,
+		},
+		{
+			name:          "unknown policy",
+			content:       `default_profile_policy = "first"`,
+			expectedError: `invalid default_profile_policy "first"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ReadConfig(writeConfig(t, tt.content))
+			if tt.expectedError != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.expectedError) {
+					t.Fatalf("Expected error %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadConfig returned an error: %v", err)
+			}
+			if policy := config.GetDefaultProfilePolicy(); policy != tt.expectedPolicy {
+				t.Errorf("Expected policy %q, got %q", tt.expectedPolicy, policy)
+			}
+		})
+	}
+}
+
+func TestReadConfigAliases(t *testing.T) {
+	config, err := ReadConfig(writeConfig(t, `
+[aliases.work]
+browser = "chrome"
+profile = "Work"
+
+[aliases.personal]
+browser = "firefox"
+`))
+	if err != nil {
+		t.Fatalf("ReadConfig returned an error: %v", err)
+	}
+	expected := map[string]ProfileAlias{
+		"work":     {Browser: "chrome", Profile: "Work"},
+		"personal": {Browser: "firefox"},
+	}
+	if !reflect.DeepEqual(expected, config.Aliases) {
+		t.Errorf("Expected aliases %v, got %v", expected, config.Aliases)
+	}
+
+	_, err = ReadConfig(writeConfig(t, `
+[aliases.work]
+profile = "Work"
+`))
+	if expectedError := `browser of alias "work" must be set`; err == nil || err.Error() != expectedError {
+		t.Errorf("Expected error %q, got %v", expectedError, err)
+	}
+}
+
+func TestReadConfigPlugins(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		content          string
+		expectedTimeouts []time.Duration
+		expectedError    string
+	}{
+		{
+			name: "valid plugins",
+			content: `
+[[plugins]]
+name = "corp-browser"
+command = "/opt/corp/browser-plugin"
+args = ["--json"]
+timeout = "3s"
+
+[[plugins]]
+name = "kiosk"
+command = "kiosk-plugin"
+`,
+			expectedTimeouts: []time.Duration{3 * time.Second, DefaultPluginTimeout},
+		},
+		{
+			name: "missing command",
+			content: `
+[[plugins]]
+name = "kiosk"
+`,
+			expectedError: `command of plugin "kiosk" must be set`,
+		},
+		{
+			name: "duplicate names",
+			content: `
+[[plugins]]
+name = "kiosk"
+command = "kiosk-plugin"
+
+[[plugins]]
+name = "kiosk"
+command = "kiosk-plugin-2"
+`,
+			expectedError: `plugin "kiosk" is declared several times`,
+		},
+		{
+			name: "invalid timeout",
+			content: `
+[[plugins]]
+name = "kiosk"
+command = "kiosk-plugin"
+timeout = "soon"
+`,
+			expectedError: `invalid timeout "soon" of plugin "kiosk"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ReadConfig(writeConfig(t, tt.content))
+			if tt.expectedError != "" {
+				if err == nil || err.Error() != tt.expectedError {
+					t.Fatalf("Expected error %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadConfig returned an error: %v", err)
+			}
+			var timeouts []time.Duration
+			for _, plugin := range config.Plugins {
+				timeouts = append(timeouts, plugin.GetTimeout())
+			}
+			if !reflect.DeepEqual(tt.expectedTimeouts, timeouts) {
+				t.Errorf("Expected timeouts %v, got %v", tt.expectedTimeouts, timeouts)
+			}
+		})
+	}
+}
+
+func TestReadConfigDevTools(t *testing.T) {
+	config, err := ReadConfig(writeConfig(t, `
+[devtools]
+chrome = "http://localhost:9222"
+`))
+	if err != nil {
+		t.Fatalf("ReadConfig returned an error: %v", err)
+	}
+	if expected := map[string]string{"chrome": "http://localhost:9222"}; !reflect.DeepEqual(expected, config.DevTools) {
+		t.Errorf("Expected devtools %v, got %v", expected, config.DevTools)
+	}
+
+	_, err = ReadConfig(writeConfig(t, `
+[devtools]
+chrome = "localhost:9222"
+`))
+	if expectedError := `invalid devtools endpoint "localhost:9222" of browser "chrome", must be an http URL`; err == nil || err.Error() != expectedError {
+		t.Errorf("Expected error %q, got %v", expectedError, err)
+	}
+}
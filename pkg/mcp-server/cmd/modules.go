@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	_ "github.com/feloy/browsers-mcp-server/pkg/browsers/arc"
 	_ "github.com/feloy/browsers-mcp-server/pkg/browsers/chrome"
+	_ "github.com/feloy/browsers-mcp-server/pkg/browsers/epiphany"
 	_ "github.com/feloy/browsers-mcp-server/pkg/browsers/firefox"
+	_ "github.com/feloy/browsers-mcp-server/pkg/browsers/qutebrowser"
 	_ "github.com/feloy/browsers-mcp-server/pkg/browsers/safari"
 )
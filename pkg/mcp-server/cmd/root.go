@@ -4,14 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 
 	"k8s.io/klog/v2"
 
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/plugin"
 	"github.com/feloy/browsers-mcp-server/pkg/config"
 	"github.com/feloy/browsers-mcp-server/pkg/genericiooptions"
 	"github.com/feloy/browsers-mcp-server/pkg/mcp"
+	"github.com/feloy/browsers-mcp-server/pkg/system"
 	"github.com/feloy/browsers-mcp-server/pkg/version"
 )
 
@@ -37,6 +41,9 @@ type MCPServerOptions struct {
 	ConfigPath   string
 	StaticConfig *config.StaticConfig
 
+	Home    string
+	Archive string
+
 	genericiooptions.IOStreams
 	Logger
 }
@@ -76,6 +83,8 @@ func NewMCPServer(streams genericiooptions.IOStreams) *cobra.Command {
 
 	cmd.Flags().BoolVar(&o.Version, "version", o.Version, "Print version information and quit")
 	cmd.Flags().StringVar(&o.ConfigPath, "config", o.ConfigPath, "Path of the config file. Each profile has its set of defaults.")
+	cmd.Flags().StringVar(&o.Home, "home", o.Home, "Home directory of the user whose browsers are read, instead of the current user's one")
+	cmd.Flags().StringVar(&o.Archive, "archive", o.Archive, "Directory, .zip or .tar.gz archive mirroring a home directory, to read the browsers from instead of the local disk. --home is then relative to the archive")
 	o.initLoggerFlags(cmd)
 	return cmd
 }
@@ -93,14 +102,50 @@ func (m *MCPServerOptions) Complete(cmd *cobra.Command) error {
 }
 
 func (m *MCPServerOptions) Validate() error {
+	if m.Archive != "" {
+		if _, err := os.Stat(system.ExpandPath(m.Archive)); err != nil {
+			return fmt.Errorf("invalid --archive: %w", err)
+		}
+	}
+	return nil
+}
+
+// configureSystem sets the locations of the browsers data, once the options are validated
+func (m *MCPServerOptions) configureSystem() error {
+	if m.Archive != "" {
+		fs, err := system.MountArchive(system.ExpandPath(m.Archive))
+		if err != nil {
+			return fmt.Errorf("failed to mount archive: %w", err)
+		}
+		system.FileSystem = fs
+		system.Home = string(filepath.Separator)
+		if m.Home != "" {
+			system.Home = filepath.Join(string(filepath.Separator), m.Home)
+		}
+	} else if m.Home != "" {
+		system.Home = system.ExpandPath(m.Home)
+	}
+	system.DataDirectories = m.StaticConfig.DataDirectories
+	plugin.Register(m.StaticConfig.Plugins)
 	return nil
 }
 
 func (m *MCPServerOptions) Run() error {
 	profile := mcp.ProfileFromString(m.Profile)
 
+	if err := m.configureSystem(); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := system.Cleanup(); err != nil {
+			klog.Errorf("failed to remove extracted files: %v", err)
+		}
+	}()
+
 	klog.V(1).Info("Starting mcp-server")
 	klog.V(1).Infof(" - Config: %s", m.ConfigPath)
+	klog.V(1).Infof(" - Home: %s", system.HomeDir())
 
 	if m.Version {
 		_, _ = fmt.Fprintf(m.Out, "%s\n", version.Version)
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/charmbracelet/log"
 	"github.com/feloy/browsers-mcp-server/pkg/browsers"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -16,19 +15,8 @@ func (s *Server) initBookmarksList() []server.ServerTool {
 		mcp.WithDescription("List the available bookmarks in the browser"),
 	}
 
-	browserProfiles := BrowsersProfiles{}
-	browserProfiles.Populate(browsers.GetBrowsers())
-	profilesEnum := browserProfiles.FlatList()
-	log.Debug("bookmarks list", "profilesEnum", profilesEnum)
-
-	if len(profilesEnum) > 0 {
-		options = append(options,
-			mcp.WithString(
-				"profile",
-				mcp.Required(),
-				mcp.Enum(profilesEnum...),
-				mcp.Description("The browser's profile to list the bookmarks for"),
-			))
+	if profileOption := s.profileOption("The browser's profile to list the bookmarks for"); profileOption != nil {
+		options = append(options, profileOption)
 	}
 	return []server.ServerTool{
 		{
@@ -41,7 +29,7 @@ func (s *Server) initBookmarksList() []server.ServerTool {
 
 func (s *Server) listBookmarks(_ context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	profileParam, _ := ctr.GetArguments()["profile"].(string)
-	browserName, profileName, err := GetBrowserAndProfileFromValue(profileParam, browsers.GetBrowsers())
+	browserName, profileName, err := s.getBrowserAndProfile(profileParam)
 	if err != nil {
 		return NewTextResult("", err), nil
 	}
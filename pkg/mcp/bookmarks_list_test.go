@@ -21,7 +21,7 @@ func TestListBookmarks(t *testing.T) {
 	var browser1 = test.NewBrowser(test.NewBrowserOptions{
 		Name:      "browser1",
 		Available: true,
-		Profiles:  []string{"profile1a"},
+		Profiles:  test.Profiles("profile1a"),
 		Bookmarks: []api.BookMark{
 			{
 				Name: "bookmark1a", URL: "https://www.bookmark1a.com", Folder: []string{"folder1a"},
@@ -34,14 +34,14 @@ func TestListBookmarks(t *testing.T) {
 	var browser2 = test.NewBrowser(test.NewBrowserOptions{
 		Name:      "browser2",
 		Available: false,
-		Profiles:  []string{"profile2"},
+		Profiles:  test.Profiles("profile2"),
 		Bookmarks: []api.BookMark{},
 	})
 
 	var browser3 = test.NewBrowser(test.NewBrowserOptions{
 		Name:      "browser3",
 		Available: true,
-		Profiles:  []string{"profile3a", "profile3b"},
+		Profiles:  test.Profiles("profile3a", "profile3b"),
 		Bookmarks: []api.BookMark{
 			{
 				Name: "bookmark3a", URL: "https://www.bookmark3a.com", Folder: []string{"folder3a"},
@@ -102,10 +102,10 @@ func TestListBookmarks(t *testing.T) {
 				{"profile"},
 			},
 			expected_input_properties_required: [][]bool{
-				{true},
+				{false},
 			},
 			expected_input_properties_descriptions: [][]string{
-				{"The browser's profile to list the bookmarks for"},
+				{"The browser's profile to list the bookmarks for. Optional, the most recently used profile is used by default"},
 			},
 			toolName: "list_bookmarks",
 			parameters: map[string]interface{}{
@@ -133,10 +133,10 @@ func TestListBookmarks(t *testing.T) {
 				{"profile"},
 			},
 			expected_input_properties_required: [][]bool{
-				{true},
+				{false},
 			},
 			expected_input_properties_descriptions: [][]string{
-				{"The browser's profile to list the bookmarks for"},
+				{"The browser's profile to list the bookmarks for. Optional, the most recently used profile is used by default"},
 			},
 			toolName: "list_bookmarks",
 			parameters: map[string]interface{}{
@@ -9,10 +9,13 @@ import (
 
 	"github.com/charmbracelet/log"
 	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers"
+	"github.com/feloy/browsers-mcp-server/pkg/config"
+	"github.com/mark3labs/mcp-go/mcp"
 )
 
-// key: browser name, value: profiles names
-type BrowsersProfiles map[string][]string
+// key: browser name, value: profiles
+type BrowsersProfiles map[string][]api.Profile
 
 func (b *BrowsersProfiles) Populate(browsers []api.Browser) {
 	for _, browser := range browsers {
@@ -25,37 +28,126 @@ func (b *BrowsersProfiles) Populate(browsers []api.Browser) {
 	}
 }
 
-func (b *BrowsersProfiles) FlatList() []string {
+// profileValue is a value of the profile enum, with the profile it designates
+type profileValue struct {
+	value   string
+	browser string
+	profile api.Profile
+}
+
+func (b *BrowsersProfiles) values() []profileValue {
 	if len(*b) == 0 {
 		// no browsers found
-		return []string{}
+		return nil
 	}
 	if len(*b) == 1 && len(slices.Collect(maps.Values(*b))[0]) == 1 {
 		// only one browser found, with a single profile
-		return []string{}
+		return nil
 	}
 
 	multipleBrowsers := len(*b) > 1
 
-	result := []string{}
-	for browserName, profiles := range *b {
-		for _, profile := range profiles {
+	var result []profileValue
+	for _, browserName := range slices.Sorted(maps.Keys(*b)) {
+		profiles := (*b)[browserName]
+		labels := profileLabels(profiles)
+		for i, profile := range profiles {
+			value := browserName
 			if len(profiles) > 1 {
+				value = labels[i]
 				if multipleBrowsers {
-					result = append(result, fmt.Sprintf("%s on %s", profile, browserName))
-				} else {
-					result = append(result, profile)
+					value = fmt.Sprintf("%s on %s", labels[i], browserName)
 				}
-			} else {
-				result = append(result, browserName)
 			}
+			result = append(result, profileValue{
+				value:   value,
+				browser: browserName,
+				profile: profile,
+			})
 		}
 	}
 	return result
 }
 
-func GetBrowserAndProfileFromValue(value string, browsers []api.Browser) (string, string, error) {
-	browserProfiles := map[string][]string{}
+func (b *BrowsersProfiles) FlatList() []string {
+	result := []string{}
+	for _, v := range b.values() {
+		result = append(result, v.value)
+	}
+	return result
+}
+
+// Description returns the details of the profiles designated by the values of the profile enum,
+// to be appended to the description of the profile parameter.
+func (b *BrowsersProfiles) Description() string {
+	var lines []string
+	for _, v := range b.values() {
+		var details []string
+		if v.profile.Default {
+			details = append(details, "default profile")
+		}
+		if len(v.profile.DefaultFor) > 0 {
+			details = append(details, "default of install "+strings.Join(v.profile.DefaultFor, ", "))
+		}
+		if v.profile.Account != "" {
+			details = append(details, "account "+v.profile.Account)
+		}
+		if !v.profile.LastUsed.IsZero() {
+			details = append(details, "last used on "+v.profile.LastUsed.Format("2006-01-02"))
+		}
+		if len(details) == 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("- %s: %s", v.value, strings.Join(details, ", ")))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "\n\nProfiles:\n" + strings.Join(lines, "\n")
+}
+
+// profileLabels returns the human-readable labels of the profiles of a browser:
+// the name of the profile, suffixed with the name of its install if any.
+// The ID of a profile is used when its label is not unique.
+func profileLabels(profiles []api.Profile) []string {
+	labels := make([]string, len(profiles))
+	counts := map[string]int{}
+	for i, profile := range profiles {
+		labels[i] = profile.Name
+		if labels[i] == "" {
+			labels[i] = profile.ID
+		}
+		if profile.Install != "" {
+			labels[i] = fmt.Sprintf("%s (%s)", labels[i], profile.Install)
+		}
+		counts[labels[i]]++
+	}
+	for i, profile := range profiles {
+		if counts[labels[i]] > 1 {
+			labels[i] = profile.ID
+		}
+	}
+	return labels
+}
+
+// findProfile returns the ID of the profile designated by its label or ID
+func findProfile(profiles []api.Profile, value string) (string, bool) {
+	labels := profileLabels(profiles)
+	for i, profile := range profiles {
+		if labels[i] == value {
+			return profile.ID, true
+		}
+	}
+	for _, profile := range profiles {
+		if profile.ID == value {
+			return profile.ID, true
+		}
+	}
+	return "", false
+}
+
+func getBrowsersProfiles(browsers []api.Browser) map[string][]api.Profile {
+	browserProfiles := map[string][]api.Profile{}
 	for _, browser := range browsers {
 		profiles, err := browser.Profiles()
 		if err != nil {
@@ -63,28 +155,40 @@ func GetBrowserAndProfileFromValue(value string, browsers []api.Browser) (string
 		}
 		browserProfiles[browser.Name()] = profiles
 	}
+	return browserProfiles
+}
+
+// GetBrowserAndProfileFromValue returns the name of the browser and the ID of the profile
+// designated by a value of the profile enum or by an alias
+func GetBrowserAndProfileFromValue(value string, browsers []api.Browser, aliases map[string]config.ProfileAlias) (string, string, error) {
+	browserProfiles := getBrowsersProfiles(browsers)
 
-	parts := strings.Split(value, " on ")
+	if alias, ok := aliases[value]; ok {
+		return resolveAlias(value, alias, browserProfiles)
+	}
 
-	if len(parts) == 2 {
-		var profiles []string
+	// profile names can contain " on ", browser names cannot
+	if i := strings.LastIndex(value, " on "); i != -1 {
+		profileValue, browserName := value[:i], value[i+len(" on "):]
+		var profiles []api.Profile
 		var ok bool
-		if profiles, ok = browserProfiles[parts[1]]; !ok {
-			return "", "", fmt.Errorf("browser %q not found", parts[1])
+		if profiles, ok = browserProfiles[browserName]; !ok {
+			return "", "", fmt.Errorf("browser %q not found", browserName)
 		}
-		if !slices.Contains(profiles, parts[0]) {
-			return "", "", fmt.Errorf("profile %q not found", parts[0])
+		profileID, ok := findProfile(profiles, profileValue)
+		if !ok {
+			return "", "", fmt.Errorf("profile %q not found", profileValue)
 		}
-		return parts[1], parts[0], nil
+		return browserName, profileID, nil
 	}
 
 	// No value: should be one browser with one profile
-	if len(parts) == 1 && len(parts[0]) == 0 {
+	if len(value) == 0 {
 		if len(browserProfiles) == 1 {
 			browserName := slices.Collect(maps.Keys(browserProfiles))[0]
 			profiles := browserProfiles[browserName]
 			if len(profiles) == 1 {
-				return browserName, profiles[0], nil
+				return browserName, profiles[0].ID, nil
 			}
 			return "", "", fmt.Errorf("multiple profiles found for browser %q, value cannot be empty", browserName)
 		}
@@ -95,17 +199,171 @@ func GetBrowserAndProfileFromValue(value string, browsers []api.Browser) (string
 
 	// - first check if this is the name of a browser having a single profile
 	if profiles, ok := browserProfiles[value]; ok && len(profiles) == 1 {
-		return value, profiles[0], nil
+		return value, profiles[0].ID, nil
 	}
 
 	// - then check if this the name of a profile of the single browser
 	if len(browserProfiles) == 1 {
 		browserName := slices.Collect(maps.Keys(browserProfiles))[0]
 		profiles := browserProfiles[browserName]
-		if slices.Contains(profiles, value) {
-			return browserName, value, nil
+		if profileID, ok := findProfile(profiles, value); ok {
+			return browserName, profileID, nil
 		}
 	}
 
 	return "", "", errors.New("incorrect profile or browser name")
 }
+
+// resolveAlias returns the name of the browser and the ID of the profile designated by an alias.
+// An alias can designate a profile missing on this machine, as a config file can be shared between machines.
+func resolveAlias(name string, alias config.ProfileAlias, browserProfiles map[string][]api.Profile) (string, string, error) {
+	target := alias.Browser
+	if alias.Profile != "" {
+		target = alias.Profile + " on " + alias.Browser
+	}
+	profiles, ok := browserProfiles[alias.Browser]
+	if !ok {
+		return "", "", fmt.Errorf("alias %q points to missing profile %q", name, target)
+	}
+	if alias.Profile == "" {
+		if len(profiles) != 1 {
+			return "", "", fmt.Errorf("alias %q points to browser %q having multiple profiles, profile must be set", name, alias.Browser)
+		}
+		return alias.Browser, profiles[0].ID, nil
+	}
+	profileID, ok := findProfile(profiles, alias.Profile)
+	if !ok {
+		return "", "", fmt.Errorf("alias %q points to missing profile %q", name, target)
+	}
+	return alias.Browser, profileID, nil
+}
+
+// describeAliases returns the profiles designated by the aliases,
+// to be appended to the description of the profile parameter.
+func describeAliases(aliases map[string]config.ProfileAlias) string {
+	if len(aliases) == 0 {
+		return ""
+	}
+	var lines []string
+	for _, name := range slices.Sorted(maps.Keys(aliases)) {
+		alias := aliases[name]
+		if alias.Profile == "" {
+			lines = append(lines, fmt.Sprintf("- %s: %s", name, alias.Browser))
+		} else {
+			lines = append(lines, fmt.Sprintf("- %s: %s on %s", name, alias.Profile, alias.Browser))
+		}
+	}
+	return "\n\nAliases:\n" + strings.Join(lines, "\n")
+}
+
+// GetDefaultBrowserAndProfile returns the name of the browser and the ID of the profile
+// to use when the profile parameter is not provided, depending on the default profile policy
+func GetDefaultBrowserAndProfile(browsers []api.Browser, staticConfig *config.StaticConfig) (string, string, error) {
+	browserName, profileID, err := GetBrowserAndProfileFromValue("", browsers, nil)
+	if err == nil {
+		return browserName, profileID, nil
+	}
+
+	switch staticConfig.GetDefaultProfilePolicy() {
+	case config.DefaultProfilePolicyConfig:
+		browserName, profileID, err := GetBrowserAndProfileFromValue(staticConfig.DefaultProfile, browsers, staticConfig.Aliases)
+		if err != nil {
+			return "", "", fmt.Errorf("default profile %q: %w", staticConfig.DefaultProfile, err)
+		}
+		return browserName, profileID, nil
+
+	case config.DefaultProfilePolicyLastUsed:
+		return getLastUsedBrowserAndProfile(browsers)
+
+	default:
+		return "", "", err
+	}
+}
+
+// getLastUsedBrowserAndProfile returns the most recently used profile among the profiles of all the browsers.
+// The default profile of a browser is preferred when the last uses are unknown.
+func getLastUsedBrowserAndProfile(browsers []api.Browser) (string, string, error) {
+	var browserName string
+	var lastUsed *api.Profile
+	for _, browser := range browsers {
+		profiles, err := browser.Profiles()
+		if err != nil {
+			continue
+		}
+		for _, profile := range profiles {
+			if lastUsed == nil ||
+				profile.LastUsed.After(lastUsed.LastUsed) ||
+				profile.LastUsed.Equal(lastUsed.LastUsed) && profile.Default && !lastUsed.Default {
+				browserName = browser.Name()
+				lastUsed = &profile
+			}
+		}
+	}
+	if lastUsed == nil {
+		return "", "", errors.New("no profile found")
+	}
+	return browserName, lastUsed.ID, nil
+}
+
+// getBrowserAndProfile returns the name of the browser and the ID of the profile designated by the profile parameter,
+// or the default ones when the parameter is not provided
+func (s *Server) getBrowserAndProfile(value string) (string, string, error) {
+	return s.getBrowserAndProfileFrom(browsers.GetBrowsers(), value)
+}
+
+// getBrowserAndProfileFrom returns the name of the browser and the ID of the profile designated by the profile parameter
+// among some browsers, or the default ones when the parameter is not provided
+func (s *Server) getBrowserAndProfileFrom(available []api.Browser, value string) (string, string, error) {
+	if value == "" {
+		return GetDefaultBrowserAndProfile(available, s.configuration.StaticConfig)
+	}
+	return GetBrowserAndProfileFromValue(value, available, s.configuration.StaticConfig.Aliases)
+}
+
+// profileOption returns the profile parameter of the tools, listing the profiles of all the browsers and the aliases.
+// It returns nil when a single profile is available.
+func (s *Server) profileOption(description string) mcp.ToolOption {
+	return s.profileOptionFrom(browsers.GetBrowsers(), description)
+}
+
+// profileOptionFrom returns the profile parameter of the tools supported by some browsers only,
+// listing the profiles of these browsers and the aliases designating them.
+// It returns nil when a single profile is available.
+func (s *Server) profileOptionFrom(available []api.Browser, description string) mcp.ToolOption {
+	browserProfiles := BrowsersProfiles{}
+	browserProfiles.Populate(available)
+	profilesEnum := browserProfiles.FlatList()
+	if len(profilesEnum) == 0 {
+		return nil
+	}
+	// an alias named as a profile is not listed twice
+	aliases := map[string]config.ProfileAlias{}
+	for name, alias := range s.configuration.StaticConfig.Aliases {
+		if _, ok := browserProfiles[alias.Browser]; ok {
+			aliases[name] = alias
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(aliases)) {
+		if !slices.Contains(profilesEnum, name) {
+			profilesEnum = append(profilesEnum, name)
+		}
+	}
+	log.Debug("profile parameter", "profilesEnum", profilesEnum)
+	return mcp.WithString(
+		"profile",
+		mcp.Enum(profilesEnum...),
+		mcp.Description(description+s.defaultProfileDescription()+browserProfiles.Description()+describeAliases(aliases)),
+	)
+}
+
+// defaultProfileDescription describes the profile used when the profile parameter is not provided
+func (s *Server) defaultProfileDescription() string {
+	switch s.configuration.StaticConfig.GetDefaultProfilePolicy() {
+	case config.DefaultProfilePolicyLastUsed:
+		return ". Optional, the most recently used profile is used by default"
+	case config.DefaultProfilePolicyConfig:
+		return fmt.Sprintf(". Optional, the profile %q is used by default", s.configuration.StaticConfig.DefaultProfile)
+	default:
+		return ""
+	}
+}
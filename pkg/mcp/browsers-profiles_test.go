@@ -2,9 +2,12 @@ package mcp
 
 import (
 	"testing"
+	"time"
 
 	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers"
 	"github.com/feloy/browsers-mcp-server/pkg/browsers/test"
+	"github.com/feloy/browsers-mcp-server/pkg/config"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -20,7 +23,7 @@ func TestBrowsersProfiles(t *testing.T) {
 				test.NewBrowser(test.NewBrowserOptions{
 					Name:      "browser1",
 					Available: true,
-					Profiles:  []string{"profile1"},
+					Profiles:  test.Profiles("profile1"),
 				}),
 			},
 			expected: []string{},
@@ -31,7 +34,7 @@ func TestBrowsersProfiles(t *testing.T) {
 				test.NewBrowser(test.NewBrowserOptions{
 					Name:      "browser1",
 					Available: true,
-					Profiles:  []string{"profile1a", "profile1b"},
+					Profiles:  test.Profiles("profile1a", "profile1b"),
 				}),
 			},
 			expected: []string{"profile1a", "profile1b"},
@@ -42,12 +45,12 @@ func TestBrowsersProfiles(t *testing.T) {
 				test.NewBrowser(test.NewBrowserOptions{
 					Name:      "browser1",
 					Available: true,
-					Profiles:  []string{"profile1"},
+					Profiles:  test.Profiles("profile1"),
 				}),
 				test.NewBrowser(test.NewBrowserOptions{
 					Name:      "browser2",
 					Available: true,
-					Profiles:  []string{"profile2"},
+					Profiles:  test.Profiles("profile2"),
 				}),
 			},
 			expected: []string{"browser1", "browser2"},
@@ -58,16 +61,46 @@ func TestBrowsersProfiles(t *testing.T) {
 				test.NewBrowser(test.NewBrowserOptions{
 					Name:      "browser1",
 					Available: true,
-					Profiles:  []string{"profile1a", "profile1b"},
+					Profiles:  test.Profiles("profile1a", "profile1b"),
 				}),
 				test.NewBrowser(test.NewBrowserOptions{
 					Name:      "browser2",
 					Available: true,
-					Profiles:  []string{"profile2"},
+					Profiles:  test.Profiles("profile2"),
 				}),
 			},
 			expected: []string{"profile1a on browser1", "profile1b on browser1", "browser2"},
 		},
+		{
+			name: "profiles are listed with their names",
+			browsers: []api.Browser{
+				test.NewBrowser(test.NewBrowserOptions{
+					Name:      "browser1",
+					Available: true,
+					Profiles: []api.Profile{
+						{ID: "Default", Name: "Personal"},
+						{ID: "Profile 3", Name: "Work"},
+						{ID: "Default (flatpak)", Name: "Personal", Install: "flatpak"},
+					},
+				}),
+			},
+			expected: []string{"Personal", "Work", "Personal (flatpak)"},
+		},
+		{
+			name: "profiles with the same name are listed with their IDs",
+			browsers: []api.Browser{
+				test.NewBrowser(test.NewBrowserOptions{
+					Name:      "browser1",
+					Available: true,
+					Profiles: []api.Profile{
+						{ID: "Default", Name: "Person 1"},
+						{ID: "Profile 1", Name: "Person 1"},
+						{ID: "Profile 2", Name: "Work"},
+					},
+				}),
+			},
+			expected: []string{"Default", "Profile 1", "Work"},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			browserProfiles := BrowsersProfiles{}
@@ -100,7 +133,7 @@ func TestGetBrowserAndProfileFromValue(t *testing.T) {
 				test.NewBrowser(test.NewBrowserOptions{
 					Name:      "browser1",
 					Available: true,
-					Profiles:  []string{"profile1"},
+					Profiles:  test.Profiles("profile1"),
 				}),
 			},
 			testValues: []testValues{
@@ -134,7 +167,7 @@ func TestGetBrowserAndProfileFromValue(t *testing.T) {
 				test.NewBrowser(test.NewBrowserOptions{
 					Name:      "browser1",
 					Available: true,
-					Profiles:  []string{"profile1a", "profile1b"},
+					Profiles:  test.Profiles("profile1a", "profile1b"),
 				}),
 			},
 			testValues: []testValues{
@@ -164,12 +197,12 @@ func TestGetBrowserAndProfileFromValue(t *testing.T) {
 				test.NewBrowser(test.NewBrowserOptions{
 					Name:      "browser1",
 					Available: true,
-					Profiles:  []string{"profile1"},
+					Profiles:  test.Profiles("profile1"),
 				}),
 				test.NewBrowser(test.NewBrowserOptions{
 					Name:      "browser2",
 					Available: true,
-					Profiles:  []string{"profile2"},
+					Profiles:  test.Profiles("profile2"),
 				}),
 			},
 			testValues: []testValues{
@@ -214,12 +247,12 @@ func TestGetBrowserAndProfileFromValue(t *testing.T) {
 				test.NewBrowser(test.NewBrowserOptions{
 					Name:      "browser1",
 					Available: true,
-					Profiles:  []string{"profile1a", "profile1b"},
+					Profiles:  test.Profiles("profile1a", "profile1b"),
 				}),
 				test.NewBrowser(test.NewBrowserOptions{
 					Name:      "browser2",
 					Available: true,
-					Profiles:  []string{"profile2"},
+					Profiles:  test.Profiles("profile2"),
 				}),
 			},
 			testValues: []testValues{
@@ -235,10 +268,49 @@ func TestGetBrowserAndProfileFromValue(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "profiles with names",
+			browsers: []api.Browser{
+				test.NewBrowser(test.NewBrowserOptions{
+					Name:      "browser1",
+					Available: true,
+					Profiles: []api.Profile{
+						{ID: "Default", Name: "Personal"},
+						{ID: "Profile 3", Name: "Work on site"},
+					},
+				}),
+				test.NewBrowser(test.NewBrowserOptions{
+					Name:      "browser2",
+					Available: true,
+					Profiles:  test.Profiles("profile2"),
+				}),
+			},
+			testValues: []testValues{
+				{
+					value:           "Personal on browser1",
+					expectedBrowser: "browser1",
+					expectedProfile: "Default",
+				},
+				{
+					value:           "Work on site on browser1",
+					expectedBrowser: "browser1",
+					expectedProfile: "Profile 3",
+				},
+				{
+					value:           "Profile 3 on browser1",
+					expectedBrowser: "browser1",
+					expectedProfile: "Profile 3",
+				},
+				{
+					value:         "Work on browser1",
+					expectedError: true,
+				},
+			},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			for _, testValue := range tt.testValues {
-				browser, profile, err := GetBrowserAndProfileFromValue(testValue.value, tt.browsers)
+				browser, profile, err := GetBrowserAndProfileFromValue(testValue.value, tt.browsers, nil)
 				if testValue.expectedError && err == nil {
 					t.Errorf("value %q: expected error, got nil", testValue.value)
 				}
@@ -255,3 +327,215 @@ func TestGetBrowserAndProfileFromValue(t *testing.T) {
 		})
 	}
 }
+
+func TestBrowsersProfilesDescription(t *testing.T) {
+	browserProfiles := BrowsersProfiles{}
+	browserProfiles.Populate([]api.Browser{
+		test.NewBrowser(test.NewBrowserOptions{
+			Name:      "browser1",
+			Available: true,
+			Profiles: []api.Profile{
+				{ID: "Default", Name: "Personal", Default: true, DefaultFor: []string{"4F96D1932A9F858E"}},
+				{ID: "Profile 3", Name: "Work", Account: "jane@example.com", LastUsed: time.Date(2025, 4, 28, 10, 0, 0, 0, time.UTC)},
+			},
+		}),
+		test.NewBrowser(test.NewBrowserOptions{
+			Name:      "browser2",
+			Available: true,
+			Profiles:  test.Profiles("profile2"),
+		}),
+	})
+	expected := `
+
+Profiles:
+- Personal on browser1: default profile, default of install 4F96D1932A9F858E
+- Work on browser1: account jane@example.com, last used on 2025-04-28`
+	if description := browserProfiles.Description(); description != expected {
+		t.Errorf("expected %q, got %q", expected, description)
+	}
+}
+
+func TestGetDefaultBrowserAndProfile(t *testing.T) {
+	older := time.Date(2025, 4, 27, 10, 0, 0, 0, time.UTC)
+	newer := time.Date(2025, 4, 28, 10, 0, 0, 0, time.UTC)
+	browsers := []api.Browser{
+		test.NewBrowser(test.NewBrowserOptions{
+			Name:      "browser1",
+			Available: true,
+			Profiles: []api.Profile{
+				{ID: "Default", Name: "Personal", Default: true, LastUsed: older},
+				{ID: "Profile 3", Name: "Work", LastUsed: newer},
+			},
+		}),
+		test.NewBrowser(test.NewBrowserOptions{
+			Name:      "browser2",
+			Available: true,
+			Profiles: []api.Profile{
+				{ID: "profile2a", Name: "profile2a"},
+				{ID: "profile2b", Name: "profile2b", Default: true},
+			},
+		}),
+	}
+
+	for _, tt := range []struct {
+		name            string
+		browsers        []api.Browser
+		staticConfig    *config.StaticConfig
+		expectedBrowser string
+		expectedProfile string
+		expectedError   bool
+	}{
+		{
+			name:            "most recently used profile",
+			browsers:        browsers,
+			staticConfig:    &config.StaticConfig{},
+			expectedBrowser: "browser1",
+			expectedProfile: "Profile 3",
+		},
+		{
+			name:            "default profile when last uses are unknown",
+			browsers:        browsers[1:],
+			staticConfig:    &config.StaticConfig{},
+			expectedBrowser: "browser2",
+			expectedProfile: "profile2b",
+		},
+		{
+			name:            "profile from config",
+			browsers:        browsers,
+			staticConfig:    &config.StaticConfig{DefaultProfile: "Personal on browser1"},
+			expectedBrowser: "browser1",
+			expectedProfile: "Default",
+		},
+		{
+			name:          "unknown profile from config",
+			browsers:      browsers,
+			staticConfig:  &config.StaticConfig{DefaultProfile: "Shopping on browser1"},
+			expectedError: true,
+		},
+		{
+			name:          "error policy",
+			browsers:      browsers,
+			staticConfig:  &config.StaticConfig{DefaultProfilePolicy: config.DefaultProfilePolicyError},
+			expectedError: true,
+		},
+		{
+			name: "single profile with error policy",
+			browsers: []api.Browser{
+				test.NewBrowser(test.NewBrowserOptions{
+					Name:      "browser1",
+					Available: true,
+					Profiles:  test.Profiles("profile1"),
+				}),
+			},
+			staticConfig:    &config.StaticConfig{DefaultProfilePolicy: config.DefaultProfilePolicyError},
+			expectedBrowser: "browser1",
+			expectedProfile: "profile1",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			browser, profile, err := GetDefaultBrowserAndProfile(tt.browsers, tt.staticConfig)
+			if tt.expectedError != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+			if browser != tt.expectedBrowser || profile != tt.expectedProfile {
+				t.Errorf("expected %q on %q, got %q on %q", tt.expectedProfile, tt.expectedBrowser, profile, browser)
+			}
+		})
+	}
+}
+
+func TestAliases(t *testing.T) {
+	browsers := []api.Browser{
+		test.NewBrowser(test.NewBrowserOptions{
+			Name:      "browser1",
+			Available: true,
+			Profiles: []api.Profile{
+				{ID: "Default", Name: "Personal"},
+				{ID: "Profile 2", Name: "Work"},
+			},
+		}),
+		test.NewBrowser(test.NewBrowserOptions{
+			Name:      "browser2",
+			Available: true,
+			Profiles:  test.Profiles("profile2"),
+		}),
+	}
+	aliases := map[string]config.ProfileAlias{
+		"work":     {Browser: "browser1", Profile: "Work"},
+		"personal": {Browser: "browser1", Profile: "Default"},
+		"other":    {Browser: "browser2"},
+	}
+
+	for value, expected := range map[string][2]string{
+		"work":                 {"browser1", "Profile 2"},
+		"personal":             {"browser1", "Default"},
+		"other":                {"browser2", "profile2"},
+		"Personal on browser1": {"browser1", "Default"},
+	} {
+		browser, profile, err := GetBrowserAndProfileFromValue(value, browsers, aliases)
+		if err != nil {
+			t.Errorf("value %q: expected no error, got %v", value, err)
+		}
+		if browser != expected[0] || profile != expected[1] {
+			t.Errorf("value %q: expected %q on %q, got %q on %q", value, expected[1], expected[0], profile, browser)
+		}
+	}
+
+	invalidAliases := map[string]config.ProfileAlias{
+		"shopping": {Browser: "browser1", Profile: "Shopping"},
+		"browser":  {Browser: "browser1"},
+		"missing":  {Browser: "browser3"},
+	}
+	for value, expectedError := range map[string]string{
+		"shopping": `alias "shopping" points to missing profile "Shopping on browser1"`,
+		"browser":  `alias "browser" points to browser "browser1" having multiple profiles, profile must be set`,
+		"missing":  `alias "missing" points to missing profile "browser3"`,
+	} {
+		if _, _, err := GetBrowserAndProfileFromValue(value, browsers, invalidAliases); err == nil || err.Error() != expectedError {
+			t.Errorf("value %q: expected error %q, got %v", value, expectedError, err)
+		}
+	}
+}
+
+func TestNewServerWithInvalidAliases(t *testing.T) {
+	browsers.Clear()
+	browsers.Register(test.NewBrowser(test.NewBrowserOptions{
+		Name:      "browser1",
+		Available: true,
+		Profiles:  test.Profiles("profile1a", "profile1b"),
+	}))
+	srv, err := NewServer(Configuration{
+		Profile: &FullProfile{},
+		StaticConfig: &config.StaticConfig{
+			Aliases: map[string]config.ProfileAlias{
+				"work":      {Browser: "browser1", Profile: "profile1b"},
+				"profile1a": {Browser: "browser1", Profile: "profile1a"},
+				"home":      {Browser: "browser1", Profile: "Home"},
+				"shopping":  {Browser: "browser3", Profile: "Shopping"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected an alias of a browser not installed not to prevent the server from starting, got %v", err)
+	}
+
+	tools := srv.initBookmarksList()
+	if len(tools) != 1 {
+		t.Fatalf("expected 1 tool, got %d", len(tools))
+	}
+	profile, ok := tools[0].Tool.InputSchema.Properties["profile"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected property profile not found")
+	}
+	if diff := cmp.Diff([]string{"profile1a", "profile1b", "home", "work"}, profile["enum"]); diff != "" {
+		t.Errorf("profile enum mismatch (-want +got):\n%s", diff)
+	}
+	for value, expectedError := range map[string]string{
+		"home":     `alias "home" points to missing profile "Home on browser1"`,
+		"shopping": `alias "shopping" points to missing profile "Shopping on browser3"`,
+	} {
+		if _, _, err := srv.getBrowserAndProfile(value); err == nil || err.Error() != expectedError {
+			t.Errorf("value %q: expected error %q, got %v", value, expectedError, err)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+func (s *Server) initCollectionsList() []server.ServerTool {
+	available := browsers.GetBrowsersWith[api.CollectionsReader]()
+	if len(available) == 0 {
+		return nil
+	}
+
+	options := []mcp.ToolOption{
+		mcp.WithDescription("List the collections of saved pages in the browser, with their items"),
+	}
+
+	if profileOption := s.profileOptionFrom(available, "The browser's profile to list the collections for"); profileOption != nil {
+		options = append(options, profileOption)
+	}
+
+	options = append(
+		options,
+		mcp.WithString(
+			"collection",
+			mcp.Description("The title of the collection to list, default is all the collections"),
+		),
+	)
+	return []server.ServerTool{
+		{
+			Tool:    mcp.NewTool("list_collections", options...),
+			Handler: s.listCollections,
+		},
+	}
+}
+
+func (s *Server) listCollections(_ context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	profileParam, _ := ctr.GetArguments()["profile"].(string)
+	browserName, profileName, err := s.getBrowserAndProfileFrom(browsers.GetBrowsersWith[api.CollectionsReader](), profileParam)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	browser, err := browsers.GetBrowserByName(browserName)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	collectionsReader, ok := browser.(api.CollectionsReader)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("browser %q does not support collections", browserName)), nil
+	}
+
+	options := api.ListCollectionsOptions{}
+	options.Collection, _ = ctr.GetArguments()["collection"].(string)
+
+	collections, err := collectionsReader.ListCollections(profileName, options)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	yamlCollections, err := yaml.Marshal(collections)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	return NewTextResult(fmt.Sprintf("The following collections (YAML format) were found:\n%s", string(yamlCollections)), nil), nil
+}
@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/test"
+	"github.com/feloy/browsers-mcp-server/pkg/config"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestListCollections(t *testing.T) {
+	var browser1 = test.NewBrowser(test.NewBrowserOptions{
+		Name:      "browser1",
+		Available: true,
+		Profiles:  test.Profiles("profile1a"),
+	})
+
+	var collectionsBrowser = test.NewCollectionsBrowser(test.NewBrowserOptions{
+		Name:      "collections",
+		Available: true,
+		Profiles:  test.Profiles("profile2a"),
+		Collections: []api.Collection{
+			{
+				Title:     "collection2a",
+				DateAdded: globaltest.Must(time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")),
+				Items: []api.CollectionItem{
+					{Title: "item2a", URL: "https://www.item2a.com", DateAdded: globaltest.Must(time.Parse(time.RFC3339, "2024-01-02T00:00:00Z"))},
+					{Note: "note2a"},
+				},
+			},
+		},
+	})
+
+	for _, tt := range []struct {
+		name               string
+		browsers           []api.Browser
+		expectedToolsCount int
+		expectedProfile    bool
+		parameters         map[string]any
+		expected           string
+	}{
+		{
+			name:               "no browser supporting collections",
+			browsers:           []api.Browser{browser1},
+			expectedToolsCount: 0,
+		},
+		{
+			name:               "single profile of the browsers supporting collections",
+			browsers:           []api.Browser{browser1, collectionsBrowser},
+			expectedToolsCount: 1,
+			expectedProfile:    false,
+			parameters:         map[string]any{},
+			expected: `The following collections (YAML format) were found:
+- title: collection2a
+  date_added: 2024-01-01T00:00:00Z
+  items:
+    - title: item2a
+      url: https://www.item2a.com
+      date_added: 2024-01-02T00:00:00Z
+    - note: note2a
+`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			browsers.Clear()
+			for _, browser := range tt.browsers {
+				browsers.Register(browser)
+			}
+			srv, err := NewServer(Configuration{
+				Profile: &FullProfile{},
+				StaticConfig: &config.StaticConfig{
+					EnabledTools: []string{"list_collections"},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create server: %v", err)
+			}
+
+			tools := srv.initCollectionsList()
+			if len(tools) != tt.expectedToolsCount {
+				t.Fatalf("Expected %d tools, got %d", tt.expectedToolsCount, len(tools))
+			}
+			if len(tools) == 0 {
+				return
+			}
+			tool := tools[0]
+			if tool.Tool.Name != "list_collections" {
+				t.Fatalf("Expected tool name to be list_collections, but is %s", tool.Tool.Name)
+			}
+			if _, found := tool.Tool.InputSchema.Properties["profile"]; found != tt.expectedProfile {
+				t.Errorf("expected profile property %v, got %v", tt.expectedProfile, found)
+			}
+
+			ctr := mcp.CallToolRequest{}
+			ctr.Params.Arguments = tt.parameters
+			result, err := tool.Handler(context.Background(), ctr)
+			if err != nil {
+				t.Fatalf("Failed to call tool: %v", err)
+			}
+			text := result.Content[0].(mcp.TextContent).Text
+			if text != tt.expected {
+				t.Fatalf("Content differs:\n%s", cmp.Diff(tt.expected, text))
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+func (s *Server) initNotesList() []server.ServerTool {
+	available := browsers.GetBrowsersWith[api.NotesReader]()
+	if len(available) == 0 {
+		return nil
+	}
+
+	options := []mcp.ToolOption{
+		mcp.WithDescription("List the notes taken by the user in the browser"),
+	}
+
+	if profileOption := s.profileOptionFrom(available, "The browser's profile to list the notes for"); profileOption != nil {
+		options = append(options, profileOption)
+	}
+
+	options = append(
+		options,
+		mcp.WithString(
+			"folder",
+			mcp.Description("List the notes of this folder and its subfolders, as a path separated with slashes (Work/Research)"),
+		),
+		mcp.WithString(
+			"text",
+			mcp.Description("List the notes containing this text in their title, content or URL"),
+		),
+		mcp.WithString(
+			"created_after",
+			mcp.Description("List the notes created on or after this day (YYYY-MM-DD)"),
+		),
+		mcp.WithString(
+			"created_before",
+			mcp.Description("List the notes created on or before this day (YYYY-MM-DD)"),
+		),
+		mcp.WithString(
+			"modified_after",
+			mcp.Description("List the notes modified on or after this day (YYYY-MM-DD)"),
+		),
+		mcp.WithString(
+			"modified_before",
+			mcp.Description("List the notes modified on or before this day (YYYY-MM-DD)"),
+		),
+	)
+	return []server.ServerTool{
+		{
+			Tool:    mcp.NewTool("list_browser_notes", options...),
+			Handler: s.listNotes,
+		},
+	}
+}
+
+func (s *Server) listNotes(_ context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	profileParam, _ := ctr.GetArguments()["profile"].(string)
+	browserName, profileName, err := s.getBrowserAndProfileFrom(browsers.GetBrowsersWith[api.NotesReader](), profileParam)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	browser, err := browsers.GetBrowserByName(browserName)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	notesReader, ok := browser.(api.NotesReader)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("browser %q does not support notes", browserName)), nil
+	}
+
+	options := api.ListNotesOptions{}
+	options.Folder, _ = ctr.GetArguments()["folder"].(string)
+	options.Text, _ = ctr.GetArguments()["text"].(string)
+	for param, value := range map[string]*time.Time{
+		"created_after":   &options.CreatedStartTime,
+		"created_before":  &options.CreatedEndTime,
+		"modified_after":  &options.ModifiedStartTime,
+		"modified_before": &options.ModifiedEndTime,
+	} {
+		dayStr, ok := ctr.GetArguments()[param].(string)
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.DateOnly, dayStr)
+		if err != nil {
+			return NewTextResult("", fmt.Errorf("%s: %w", param, err)), nil
+		}
+		*value = t
+	}
+	// the days of the "before" parameters are included
+	if !options.CreatedEndTime.IsZero() {
+		options.CreatedEndTime = options.CreatedEndTime.AddDate(0, 0, 1)
+	}
+	if !options.ModifiedEndTime.IsZero() {
+		options.ModifiedEndTime = options.ModifiedEndTime.AddDate(0, 0, 1)
+	}
+
+	notes, err := notesReader.ListNotes(profileName, options)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	yamlNotes, err := yaml.Marshal(notes)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	return NewTextResult(fmt.Sprintf("The following notes (YAML format) were found:\n%s", string(yamlNotes)), nil), nil
+}
@@ -0,0 +1,144 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/test"
+	"github.com/feloy/browsers-mcp-server/pkg/config"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestListNotes(t *testing.T) {
+	var browser1 = test.NewBrowser(test.NewBrowserOptions{
+		Name:      "browser1",
+		Available: true,
+		Profiles:  test.Profiles("profile1a"),
+	})
+
+	var notesBrowser = test.NewNotesBrowser(test.NewBrowserOptions{
+		Name:      "notes",
+		Available: true,
+		Profiles:  test.Profiles("profile2a", "profile2b"),
+		Notes: []api.Note{
+			{
+				Title:     "note2a",
+				Content:   "content of note2a",
+				URL:       "https://www.note2a.com",
+				Folder:    []string{"folder2a"},
+				DateAdded: globaltest.Must(time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")),
+			},
+		},
+	})
+
+	for _, tt := range []struct {
+		name               string
+		browsers           []api.Browser
+		expectedToolsCount int
+		expectedEnum       []string
+		parameters         map[string]any
+		expectedError      bool
+		expected           string
+	}{
+		{
+			name:               "no browser supporting notes",
+			browsers:           []api.Browser{browser1},
+			expectedToolsCount: 0,
+		},
+		{
+			name:               "only the profiles of the browsers supporting notes are listed",
+			browsers:           []api.Browser{browser1, notesBrowser},
+			expectedToolsCount: 1,
+			expectedEnum:       []string{"profile2a", "profile2b"},
+			parameters: map[string]any{
+				"profile":       "profile2b",
+				"created_after": "2024-01-01",
+			},
+			expected: `The following notes (YAML format) were found:
+- title: note2a
+  content: content of note2a
+  url: https://www.note2a.com
+  folder:
+    - folder2a
+  date_added: 2024-01-01T00:00:00Z
+`,
+		},
+		{
+			name:               "profile of a browser not supporting notes",
+			browsers:           []api.Browser{browser1, notesBrowser},
+			expectedToolsCount: 1,
+			expectedEnum:       []string{"profile2a", "profile2b"},
+			parameters: map[string]any{
+				"profile": "profile1a on browser1",
+			},
+			expectedError: true,
+			expected:      `browser "browser1" not found`,
+		},
+		{
+			name:               "invalid date",
+			browsers:           []api.Browser{notesBrowser},
+			expectedToolsCount: 1,
+			expectedEnum:       []string{"profile2a", "profile2b"},
+			parameters: map[string]any{
+				"profile":        "profile2a",
+				"modified_after": "yesterday",
+			},
+			expectedError: true,
+			expected:      `modified_after: parsing time "yesterday" as "2006-01-02": cannot parse "yesterday" as "2006"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			browsers.Clear()
+			for _, browser := range tt.browsers {
+				browsers.Register(browser)
+			}
+			srv, err := NewServer(Configuration{
+				Profile: &FullProfile{},
+				StaticConfig: &config.StaticConfig{
+					EnabledTools: []string{"list_browser_notes"},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create server: %v", err)
+			}
+
+			tools := srv.initNotesList()
+			if len(tools) != tt.expectedToolsCount {
+				t.Fatalf("Expected %d tools, got %d", tt.expectedToolsCount, len(tools))
+			}
+			if len(tools) == 0 {
+				return
+			}
+			tool := tools[0]
+			if tool.Tool.Name != "list_browser_notes" {
+				t.Fatalf("Expected tool name to be list_browser_notes, but is %s", tool.Tool.Name)
+			}
+			profile, ok := tool.Tool.InputSchema.Properties["profile"].(map[string]any)
+			if !ok {
+				t.Fatalf("expected property profile not found")
+			}
+			if diff := cmp.Diff(tt.expectedEnum, profile["enum"]); diff != "" {
+				t.Errorf("profile enum mismatch (-want +got):\n%s", diff)
+			}
+
+			ctr := mcp.CallToolRequest{}
+			ctr.Params.Arguments = tt.parameters
+			result, err := tool.Handler(context.Background(), ctr)
+			if err != nil {
+				t.Fatalf("Failed to call tool: %v", err)
+			}
+			if result.IsError != tt.expectedError {
+				t.Errorf("expected error %v, got %v", tt.expectedError, result.IsError)
+			}
+			text := result.Content[0].(mcp.TextContent).Text
+			if text != tt.expected {
+				t.Fatalf("Content differs:\n%s", cmp.Diff(tt.expected, text))
+			}
+		})
+	}
+}
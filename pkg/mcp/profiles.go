@@ -40,6 +40,10 @@ func (p *FullProfile) GetTools(s *Server) []server.ServerTool {
 		s.initBookmarksList(),
 		s.initSearchEngineQueries(),
 		s.initSourceReposVisits(),
+		s.initNotesList(),
+		s.initCollectionsList(),
+		s.initOpenTabsList(),
+		s.initSyncedTabsList(),
 	)
 }
 
@@ -38,18 +38,8 @@ func (s *Server) getListSearchEngineQueries() ([]server.ServerTool, error) {
 		mcp.WithDescription("list queries in search engines"),
 	}
 
-	browserProfiles := BrowsersProfiles{}
-	browserProfiles.Populate(browsers.GetBrowsers())
-	profilesEnum := browserProfiles.FlatList()
-
-	if len(profilesEnum) > 0 {
-		options = append(options,
-			mcp.WithString(
-				"profile",
-				mcp.Required(),
-				mcp.Enum(profilesEnum...),
-				mcp.Description("The browser's profile to list the search engine queries for"),
-			))
+	if profileOption := s.profileOption("The browser's profile to list the search engine queries for"); profileOption != nil {
+		options = append(options, profileOption)
 	}
 
 	options = append(
@@ -78,18 +68,8 @@ func (s *Server) getListVisitedPagesFromSearchEngineQuery() ([]server.ServerTool
 		mcp.WithDescription("list the pages visited after doing a specific query in a search engine"),
 	}
 
-	browserProfiles := BrowsersProfiles{}
-	browserProfiles.Populate(browsers.GetBrowsers())
-	profilesEnum := browserProfiles.FlatList()
-
-	if len(profilesEnum) > 0 {
-		options = append(options,
-			mcp.WithString(
-				"profile",
-				mcp.Required(),
-				mcp.Enum(profilesEnum...),
-				mcp.Description("The browser's profile to list the visited pages for"),
-			))
+	if profileOption := s.profileOption("The browser's profile to list the visited pages for"); profileOption != nil {
+		options = append(options, profileOption)
 	}
 
 	options = append(
@@ -115,7 +95,7 @@ func (s *Server) getListVisitedPagesFromSearchEngineQuery() ([]server.ServerTool
 
 func (s *Server) listSearchEnginesQueries(_ context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	profileParam, _ := ctr.GetArguments()["profile"].(string)
-	browserName, profileName, err := GetBrowserAndProfileFromValue(profileParam, browsers.GetBrowsers())
+	browserName, profileName, err := s.getBrowserAndProfile(profileParam)
 	if err != nil {
 		return NewTextResult("", err), nil
 	}
@@ -161,7 +141,7 @@ func (s *Server) listSearchEnginesQueries(_ context.Context, ctr mcp.CallToolReq
 
 func (s *Server) listVisitedPagesFromSearchEngineQuery(_ context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	profileParam, _ := ctr.GetArguments()["profile"].(string)
-	browserName, profileName, err := GetBrowserAndProfileFromValue(profileParam, browsers.GetBrowsers())
+	browserName, profileName, err := s.getBrowserAndProfile(profileParam)
 	if err != nil {
 		return NewTextResult("", err), nil
 	}
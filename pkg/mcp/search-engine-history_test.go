@@ -21,7 +21,7 @@ func TestListSearchEngineHistory(t *testing.T) {
 	var browser1 = test.NewBrowser(test.NewBrowserOptions{
 		Name:      "browser1",
 		Available: true,
-		Profiles:  []string{"profile1a"},
+		Profiles:  test.Profiles("profile1a"),
 		SearchEngineQueries: []api.SearchEngineQuery{
 			{
 				Query:        "where is charly",
@@ -34,14 +34,14 @@ func TestListSearchEngineHistory(t *testing.T) {
 	var browser2 = test.NewBrowser(test.NewBrowserOptions{
 		Name:                "browser2",
 		Available:           false,
-		Profiles:            []string{"profile2"},
+		Profiles:            test.Profiles("profile2"),
 		SearchEngineQueries: []api.SearchEngineQuery{},
 	})
 
 	var browser3 = test.NewBrowser(test.NewBrowserOptions{
 		Name:      "browser3",
 		Available: true,
-		Profiles:  []string{"profile3a", "profile3b"},
+		Profiles:  test.Profiles("profile3a", "profile3b"),
 		SearchEngineQueries: []api.SearchEngineQuery{
 			{
 				Query:        "what is it",
@@ -121,17 +121,17 @@ func TestListSearchEngineHistory(t *testing.T) {
 				{"profile", "query", "day"},
 			},
 			expected_input_properties_required: [][]bool{
-				{true, false, false},
-				{true, true, false},
+				{false, false, false},
+				{false, true, false},
 			},
 			expected_input_properties_descriptions: [][]string{
 				{
-					"The browser's profile to list the search engine queries for",
+					"The browser's profile to list the search engine queries for. Optional, the most recently used profile is used by default",
 					"List the search engine queries done on this day (YYYY-MM-DD), default is today",
 					"The maximum number of search engine queries to list, default is 10",
 				},
 				{
-					"The browser's profile to list the visited pages for",
+					"The browser's profile to list the visited pages for. Optional, the most recently used profile is used by default",
 					"The query string to list the visited pages for",
 					"List the visited pages for queries done on this day (YYYY-MM-DD), default is today",
 				},
@@ -163,17 +163,17 @@ func TestListSearchEngineHistory(t *testing.T) {
 				{"profile", "query", "day"},
 			},
 			expected_input_properties_required: [][]bool{
-				{true, false, false},
-				{true, true, false},
+				{false, false, false},
+				{false, true, false},
 			},
 			expected_input_properties_descriptions: [][]string{
 				{
-					"The browser's profile to list the search engine queries for",
+					"The browser's profile to list the search engine queries for. Optional, the most recently used profile is used by default",
 					"List the search engine queries done on this day (YYYY-MM-DD), default is today",
 					"The maximum number of search engine queries to list, default is 10",
 				},
 				{
-					"The browser's profile to list the visited pages for",
+					"The browser's profile to list the visited pages for. Optional, the most recently used profile is used by default",
 					"The query string to list the visited pages for",
 					"List the visited pages for queries done on this day (YYYY-MM-DD), default is today",
 				},
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/charmbracelet/log"
 	"github.com/feloy/browsers-mcp-server/pkg/api"
 	"github.com/feloy/browsers-mcp-server/pkg/browsers"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,19 +17,8 @@ func (s *Server) initSourceReposVisits() []server.ServerTool {
 		mcp.WithDescription("List the source repositories pages visited in the browser"),
 	}
 
-	browserProfiles := BrowsersProfiles{}
-	browserProfiles.Populate(browsers.GetBrowsers())
-	profilesEnum := browserProfiles.FlatList()
-	log.Debug("source repos visits", "profilesEnum", profilesEnum)
-
-	if len(profilesEnum) > 0 {
-		options = append(options,
-			mcp.WithString(
-				"profile",
-				mcp.Required(),
-				mcp.Enum(profilesEnum...),
-				mcp.Description("The browser's profile to list the visits for"),
-			))
+	if profileOption := s.profileOption("The browser's profile to list the visits for"); profileOption != nil {
+		options = append(options, profileOption)
 	}
 	options = append(
 		options,
@@ -70,7 +58,7 @@ func (s *Server) initSourceReposVisits() []server.ServerTool {
 
 func (s *Server) listSourceReposVisits(_ context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	profileParam, _ := ctr.GetArguments()["profile"].(string)
-	browserName, profileName, err := GetBrowserAndProfileFromValue(profileParam, browsers.GetBrowsers())
+	browserName, profileName, err := s.getBrowserAndProfile(profileParam)
 	if err != nil {
 		return NewTextResult("", err), nil
 	}
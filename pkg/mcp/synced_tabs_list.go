@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+func (s *Server) initSyncedTabsList() []server.ServerTool {
+	available := browsers.GetBrowsersWith[api.SyncedTabsReader]()
+	if len(available) == 0 {
+		return nil
+	}
+
+	options := []mcp.ToolOption{
+		mcp.WithDescription("List the tabs open on the other devices of the user (phone, tablet, other computers), synced by the browser, grouped by device, the most recently synced device first"),
+	}
+
+	if profileOption := s.profileOptionFrom(available, "The browser's profile to list the synced tabs for"); profileOption != nil {
+		options = append(options, profileOption)
+	}
+	return []server.ServerTool{
+		{
+			Tool:    mcp.NewTool("list_synced_tabs", options...),
+			Handler: s.listSyncedTabs,
+		},
+	}
+}
+
+func (s *Server) listSyncedTabs(_ context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	profileParam, _ := ctr.GetArguments()["profile"].(string)
+	browserName, profileName, err := s.getBrowserAndProfileFrom(browsers.GetBrowsersWith[api.SyncedTabsReader](), profileParam)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	browser, err := browsers.GetBrowserByName(browserName)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	syncedTabsReader, ok := browser.(api.SyncedTabsReader)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("browser %q does not support synced tabs", browserName)), nil
+	}
+
+	devices, err := syncedTabsReader.ListSyncedTabs(profileName)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	yamlDevices, err := yaml.Marshal(devices)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	return NewTextResult(fmt.Sprintf("The following devices and their tabs (YAML format) were found:\n%s", string(yamlDevices)), nil), nil
+}
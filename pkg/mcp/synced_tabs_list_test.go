@@ -0,0 +1,132 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/test"
+	"github.com/feloy/browsers-mcp-server/pkg/config"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestListSyncedTabs(t *testing.T) {
+	var browser1 = test.NewBrowser(test.NewBrowserOptions{
+		Name:      "browser1",
+		Available: true,
+		Profiles:  test.Profiles("profile1a"),
+	})
+
+	var syncedTabsBrowser = test.NewSyncedTabsBrowser(test.NewBrowserOptions{
+		Name:      "synced",
+		Available: true,
+		Profiles:  test.Profiles("profile2a"),
+		SyncedDevices: []api.SyncedDevice{
+			{
+				Name:         "phone",
+				LastModified: globaltest.Must(time.Parse(time.RFC3339, "2024-01-02T00:00:00Z")),
+				Tabs: []api.SyncedTab{
+					{Title: "tab2a", URL: "https://www.tab2a.com", LastUsed: globaltest.Must(time.Parse(time.RFC3339, "2024-01-01T00:00:00Z"))},
+				},
+			},
+			{
+				Name: "laptop",
+				Tabs: []api.SyncedTab{},
+			},
+		},
+	})
+
+	var failingBrowser = test.NewSyncedTabsBrowser(test.NewBrowserOptions{
+		Name:            "failing",
+		Available:       true,
+		Profiles:        test.Profiles("profile3a"),
+		SyncedTabsError: errors.New("database is locked"),
+	})
+
+	for _, tt := range []struct {
+		name               string
+		browsers           []api.Browser
+		expectedToolsCount int
+		expectedProfile    bool
+		parameters         map[string]any
+		expected           string
+	}{
+		{
+			name:               "no browser supporting synced tabs",
+			browsers:           []api.Browser{browser1},
+			expectedToolsCount: 0,
+		},
+		{
+			name:               "single profile of the browsers supporting synced tabs",
+			browsers:           []api.Browser{browser1, syncedTabsBrowser},
+			expectedToolsCount: 1,
+			expectedProfile:    false,
+			parameters:         map[string]any{},
+			expected: `The following devices and their tabs (YAML format) were found:
+- name: phone
+  last_modified: 2024-01-02T00:00:00Z
+  tabs:
+    - title: tab2a
+      url: https://www.tab2a.com
+      last_used: 2024-01-01T00:00:00Z
+- name: laptop
+  tabs: []
+`,
+		},
+		{
+			name:               "error reading the synced tabs",
+			browsers:           []api.Browser{syncedTabsBrowser, failingBrowser},
+			expectedToolsCount: 1,
+			expectedProfile:    true,
+			parameters:         map[string]any{"profile": "profile3a on failing"},
+			expected:           "database is locked",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			browsers.Clear()
+			for _, browser := range tt.browsers {
+				browsers.Register(browser)
+			}
+			srv, err := NewServer(Configuration{
+				Profile: &FullProfile{},
+				StaticConfig: &config.StaticConfig{
+					EnabledTools: []string{"list_synced_tabs"},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create server: %v", err)
+			}
+
+			tools := srv.initSyncedTabsList()
+			if len(tools) != tt.expectedToolsCount {
+				t.Fatalf("Expected %d tools, got %d", tt.expectedToolsCount, len(tools))
+			}
+			if len(tools) == 0 {
+				return
+			}
+			tool := tools[0]
+			if tool.Tool.Name != "list_synced_tabs" {
+				t.Fatalf("Expected tool name to be list_synced_tabs, but is %s", tool.Tool.Name)
+			}
+			if _, found := tool.Tool.InputSchema.Properties["profile"]; found != tt.expectedProfile {
+				t.Errorf("expected profile property %v, got %v", tt.expectedProfile, found)
+			}
+
+			ctr := mcp.CallToolRequest{}
+			ctr.Params.Arguments = tt.parameters
+			result, err := tool.Handler(context.Background(), ctr)
+			if err != nil {
+				t.Fatalf("Failed to call tool: %v", err)
+			}
+			text := result.Content[0].(mcp.TextContent).Text
+			if text != tt.expected {
+				t.Fatalf("Content differs:\n%s", cmp.Diff(tt.expected, text))
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+func (s *Server) initOpenTabsList() []server.ServerTool {
+	return slices.Concat(
+		s.getListOpenTabs(),
+		s.getListRecentlyClosedTabs(),
+	)
+}
+
+func (s *Server) getListOpenTabs() []server.ServerTool {
+	available := browsers.GetBrowsersWith[api.OpenTabsReader]()
+	if len(available) == 0 {
+		return nil
+	}
+
+	options := []mcp.ToolOption{
+		mcp.WithDescription("List the tabs currently open in the browser"),
+	}
+
+	if profileOption := s.profileOptionFrom(available, "The browser's profile to list the open tabs for"); profileOption != nil {
+		options = append(options, profileOption)
+	}
+	return []server.ServerTool{
+		{
+			Tool:    mcp.NewTool("list_open_tabs", options...),
+			Handler: s.listOpenTabs,
+		},
+	}
+}
+
+func (s *Server) getListRecentlyClosedTabs() []server.ServerTool {
+	available := browsers.GetBrowsersWith[api.RecentlyClosedReader]()
+	if len(available) == 0 {
+		return nil
+	}
+
+	options := []mcp.ToolOption{
+		mcp.WithDescription("List the tabs and windows recently closed in the browser"),
+	}
+
+	if profileOption := s.profileOptionFrom(available, "The browser's profile to list the recently closed tabs for"); profileOption != nil {
+		options = append(options, profileOption)
+	}
+	return []server.ServerTool{
+		{
+			Tool:    mcp.NewTool("list_recently_closed_tabs", options...),
+			Handler: s.listRecentlyClosedTabs,
+		},
+	}
+}
+
+func (s *Server) listOpenTabs(_ context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	profileParam, _ := ctr.GetArguments()["profile"].(string)
+	browserName, profileName, err := s.getBrowserAndProfileFrom(browsers.GetBrowsersWith[api.OpenTabsReader](), profileParam)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	browser, err := browsers.GetBrowserByName(browserName)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	openTabsReader, ok := browser.(api.OpenTabsReader)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("browser %q does not support listing open tabs", browserName)), nil
+	}
+
+	tabs, err := openTabsReader.ListOpenTabs(profileName, api.ListOpenTabsOptions{
+		DevToolsEndpoint: s.configuration.StaticConfig.DevTools[browserName],
+	})
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	yamlTabs, err := yaml.Marshal(tabs)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	return NewTextResult(fmt.Sprintf("The following open tabs (YAML format) were found:\n%s", string(yamlTabs)), nil), nil
+}
+
+func (s *Server) listRecentlyClosedTabs(_ context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	profileParam, _ := ctr.GetArguments()["profile"].(string)
+	browserName, profileName, err := s.getBrowserAndProfileFrom(browsers.GetBrowsersWith[api.RecentlyClosedReader](), profileParam)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	browser, err := browsers.GetBrowserByName(browserName)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	recentlyClosedReader, ok := browser.(api.RecentlyClosedReader)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("browser %q does not support listing recently closed tabs", browserName)), nil
+	}
+
+	recentlyClosed, err := recentlyClosedReader.ListRecentlyClosed(profileName)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	yamlRecentlyClosed, err := yaml.Marshal(recentlyClosed)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	return NewTextResult(fmt.Sprintf("The following recently closed tabs and windows (YAML format) were found:\n%s", string(yamlRecentlyClosed)), nil), nil
+}
@@ -0,0 +1,280 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/feloy/browsers-mcp-server/pkg/api"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers"
+	"github.com/feloy/browsers-mcp-server/pkg/browsers/test"
+	"github.com/feloy/browsers-mcp-server/pkg/config"
+	globaltest "github.com/feloy/browsers-mcp-server/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestListOpenTabs(t *testing.T) {
+	var browser1 = test.NewBrowser(test.NewBrowserOptions{
+		Name:      "browser1",
+		Available: true,
+		Profiles:  test.Profiles("profile1a"),
+	})
+
+	var tabsBrowser = test.NewTabsBrowser(test.NewBrowserOptions{
+		Name:      "tabs",
+		Available: true,
+		Profiles:  test.Profiles("profile2a", "profile2b"),
+		OpenTabs: []api.Tab{
+			{Title: "tab2a", URL: "https://www.tab2a.com", Type: "page"},
+		},
+	})
+
+	var failingBrowser = test.NewTabsBrowser(test.NewBrowserOptions{
+		Name:          "failing",
+		Available:     true,
+		Profiles:      test.Profiles("profile3a"),
+		OpenTabsError: errors.New("failing is not running with --remote-debugging-port"),
+	})
+
+	for _, tt := range []struct {
+		name               string
+		browsers           []api.Browser
+		expectedToolsCount int
+		expectedEnum       any
+		parameters         map[string]any
+		expectedError      bool
+		expected           string
+	}{
+		{
+			name:               "no browser supporting open tabs",
+			browsers:           []api.Browser{browser1},
+			expectedToolsCount: 0,
+		},
+		{
+			name:               "only the profiles of the browsers supporting open tabs are listed",
+			browsers:           []api.Browser{browser1, tabsBrowser},
+			expectedToolsCount: 1,
+			expectedEnum:       []string{"profile2a", "profile2b"},
+			parameters:         map[string]any{"profile": "profile2b"},
+			expected: `The following open tabs (YAML format) were found:
+- title: tab2a
+  url: https://www.tab2a.com
+  type: page
+`,
+		},
+		{
+			name:               "profile of a browser not supporting open tabs",
+			browsers:           []api.Browser{browser1, tabsBrowser},
+			expectedToolsCount: 1,
+			expectedEnum:       []string{"profile2a", "profile2b"},
+			parameters:         map[string]any{"profile": "profile1a on browser1"},
+			expectedError:      true,
+			expected:           `browser "browser1" not found`,
+		},
+		{
+			name:               "error reading the open tabs",
+			browsers:           []api.Browser{failingBrowser},
+			expectedToolsCount: 1,
+			parameters:         map[string]any{},
+			expectedError:      true,
+			expected:           "failing is not running with --remote-debugging-port",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			browsers.Clear()
+			for _, browser := range tt.browsers {
+				browsers.Register(browser)
+			}
+			srv, err := NewServer(Configuration{
+				Profile: &FullProfile{},
+				StaticConfig: &config.StaticConfig{
+					EnabledTools: []string{"list_open_tabs"},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create server: %v", err)
+			}
+
+			tools := srv.initOpenTabsList()
+			if len(tools) != tt.expectedToolsCount {
+				t.Fatalf("Expected %d tools, got %d", tt.expectedToolsCount, len(tools))
+			}
+			if len(tools) == 0 {
+				return
+			}
+			tool := tools[0]
+			if tool.Tool.Name != "list_open_tabs" {
+				t.Fatalf("Expected tool name to be list_open_tabs, but is %s", tool.Tool.Name)
+			}
+			var enum any
+			if profile, ok := tool.Tool.InputSchema.Properties["profile"].(map[string]any); ok {
+				enum = profile["enum"]
+			}
+			if diff := cmp.Diff(tt.expectedEnum, enum); diff != "" {
+				t.Errorf("profile enum mismatch (-want +got):\n%s", diff)
+			}
+
+			ctr := mcp.CallToolRequest{}
+			ctr.Params.Arguments = tt.parameters
+			result, err := tool.Handler(context.Background(), ctr)
+			if err != nil {
+				t.Fatalf("Failed to call tool: %v", err)
+			}
+			if result.IsError != tt.expectedError {
+				t.Errorf("expected error %v, got %v", tt.expectedError, result.IsError)
+			}
+			text := result.Content[0].(mcp.TextContent).Text
+			if text != tt.expected {
+				t.Fatalf("Content differs:\n%s", cmp.Diff(tt.expected, text))
+			}
+		})
+	}
+}
+
+func TestListOpenTabsDevToolsEndpoint(t *testing.T) {
+	tabsBrowser := test.NewTabsBrowser(test.NewBrowserOptions{
+		Name:      "tabs",
+		Available: true,
+		Profiles:  test.Profiles("profile1a"),
+	})
+	browsers.Clear()
+	browsers.Register(tabsBrowser)
+	srv, err := NewServer(Configuration{
+		Profile: &FullProfile{},
+		StaticConfig: &config.StaticConfig{
+			DevTools: map[string]string{"tabs": "http://localhost:9222", "other": "http://localhost:9223"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	result, err := srv.listOpenTabs(context.Background(), mcp.CallToolRequest{})
+	if err != nil || result.IsError {
+		t.Fatalf("expected no error, got %v, %v", err, result.Content)
+	}
+	if expected := (api.ListOpenTabsOptions{DevToolsEndpoint: "http://localhost:9222"}); tabsBrowser.OpenTabsOptions != expected {
+		t.Errorf("expected options %v, got %v", expected, tabsBrowser.OpenTabsOptions)
+	}
+}
+
+func TestListRecentlyClosedTabs(t *testing.T) {
+	var tabsBrowser = test.NewTabsBrowser(test.NewBrowserOptions{
+		Name:      "tabs",
+		Available: true,
+		Profiles:  test.Profiles("profile1a"),
+	})
+
+	var recentlyClosedBrowser = test.NewRecentlyClosedBrowser(test.NewBrowserOptions{
+		Name:      "closed",
+		Available: true,
+		Profiles:  test.Profiles("profile2a"),
+		RecentlyClosed: api.RecentlyClosed{
+			Tabs: []api.ClosedTab{
+				{Title: "tab2a", URL: "https://www.tab2a.com", ClosedAt: globaltest.Must(time.Parse(time.RFC3339, "2024-01-01T00:00:00Z"))},
+			},
+			Windows: []api.ClosedWindow{
+				{
+					Title: "window2a",
+					Tabs:  []api.Tab{{Title: "tab2b", URL: "https://www.tab2b.com"}},
+				},
+			},
+		},
+	})
+
+	var failingBrowser = test.NewRecentlyClosedBrowser(test.NewBrowserOptions{
+		Name:                "failing",
+		Available:           true,
+		Profiles:            test.Profiles("profile3a"),
+		RecentlyClosedError: errors.New("invalid mozLz4 file"),
+	})
+
+	for _, tt := range []struct {
+		name               string
+		browsers           []api.Browser
+		expectedToolsCount int
+		expectedProfile    bool
+		parameters         map[string]any
+		expectedError      bool
+		expected           string
+	}{
+		{
+			name:               "no browser supporting recently closed tabs",
+			browsers:           []api.Browser{tabsBrowser},
+			expectedToolsCount: 0,
+		},
+		{
+			name:               "single profile of the browsers supporting recently closed tabs",
+			browsers:           []api.Browser{tabsBrowser, recentlyClosedBrowser},
+			expectedToolsCount: 1,
+			expectedProfile:    false,
+			parameters:         map[string]any{},
+			expected: `The following recently closed tabs and windows (YAML format) were found:
+tabs:
+    - title: tab2a
+      url: https://www.tab2a.com
+      closed_at: 2024-01-01T00:00:00Z
+windows:
+    - title: window2a
+      tabs:
+        - title: tab2b
+          url: https://www.tab2b.com
+`,
+		},
+		{
+			name:               "error reading the recently closed tabs",
+			browsers:           []api.Browser{recentlyClosedBrowser, failingBrowser},
+			expectedToolsCount: 1,
+			expectedProfile:    true,
+			parameters:         map[string]any{"profile": "profile3a on failing"},
+			expectedError:      true,
+			expected:           "invalid mozLz4 file",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			browsers.Clear()
+			for _, browser := range tt.browsers {
+				browsers.Register(browser)
+			}
+			srv, err := NewServer(Configuration{
+				Profile: &FullProfile{},
+				StaticConfig: &config.StaticConfig{
+					EnabledTools: []string{"list_recently_closed_tabs"},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create server: %v", err)
+			}
+
+			tools := srv.getListRecentlyClosedTabs()
+			if len(tools) != tt.expectedToolsCount {
+				t.Fatalf("Expected %d tools, got %d", tt.expectedToolsCount, len(tools))
+			}
+			if len(tools) == 0 {
+				return
+			}
+			tool := tools[0]
+			if tool.Tool.Name != "list_recently_closed_tabs" {
+				t.Fatalf("Expected tool name to be list_recently_closed_tabs, but is %s", tool.Tool.Name)
+			}
+			if _, found := tool.Tool.InputSchema.Properties["profile"]; found != tt.expectedProfile {
+				t.Errorf("expected profile property %v, got %v", tt.expectedProfile, found)
+			}
+
+			ctr := mcp.CallToolRequest{}
+			ctr.Params.Arguments = tt.parameters
+			result, err := tool.Handler(context.Background(), ctr)
+			if err != nil {
+				t.Fatalf("Failed to call tool: %v", err)
+			}
+			if result.IsError != tt.expectedError {
+				t.Errorf("expected error %v, got %v", tt.expectedError, result.IsError)
+			}
+			text := result.Content[0].(mcp.TextContent).Text
+			if text != tt.expected {
+				t.Fatalf("Content differs:\n%s", cmp.Diff(tt.expected, text))
+			}
+		})
+	}
+}
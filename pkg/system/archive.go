@@ -0,0 +1,68 @@
+package system
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/afero/tarfs"
+	"github.com/spf13/afero/zipfs"
+)
+
+// MountArchive returns a read-only file system exposing the content of a directory, a `.zip` or a `.tar.gz` archive,
+// whose root mirrors a home directory.
+func MountArchive(path string) (afero.Fs, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return afero.NewBasePathFs(afero.NewOsFs(), path), nil
+	}
+
+	switch {
+	case strings.HasSuffix(path, ".zip"):
+		r, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, err
+		}
+		// the reader stays open while the archive is mounted
+		return zipfs.New(&r.Reader), nil
+
+	case strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz"):
+		return mountTarGz(path)
+
+	default:
+		return nil, fmt.Errorf("unsupported archive %s, must be a directory, a .zip or a .tar.gz file", path)
+	}
+}
+
+func mountTarGz(path string) (_ afero.Fs, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	// tarfs panics on truncated archives
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("unable to read tar archive %s: %v", path, r)
+		}
+	}()
+	// tarfs reads the whole archive in memory
+	fs := tarfs.New(tar.NewReader(gz))
+	if fs == nil {
+		return nil, fmt.Errorf("unable to read tar archive %s", path)
+	}
+	return fs, nil
+}
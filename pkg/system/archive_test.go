@@ -0,0 +1,123 @@
+package system
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+var archiveFiles = map[string]string{
+	".config/chromium/Local State":     `{}`,
+	".config/chromium/Default/History": "history",
+}
+
+func writeZip(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "home.zip")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+	for name, content := range archiveFiles {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write([]byte(content))
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func writeTarGz(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "home.tar.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	w := tar.NewWriter(gz)
+	for name, content := range archiveFiles {
+		w.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		w.Write([]byte(content))
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func writeDirectory(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range archiveFiles {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestMountArchive(t *testing.T) {
+	defer func() { FileSystem = afero.NewOsFs() }()
+	defer Cleanup()
+
+	for name, archive := range map[string]func(t *testing.T) string{
+		"directory": writeDirectory,
+		"zip":       writeZip,
+		"tar.gz":    writeTarGz,
+	} {
+		t.Run(name, func(t *testing.T) {
+			fs, err := MountArchive(archive(t))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			FileSystem = fs
+			path := filepath.Join(string(filepath.Separator), ".config", "chromium", "Default", "History")
+			data, err := ReadFile(path)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if string(data) != "history" {
+				t.Errorf("expected %q, got %q", "history", string(data))
+			}
+
+			local, err := snapshot(path)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			data, err = os.ReadFile(local)
+			if err != nil {
+				t.Fatalf("expected local file to exist, got %v", err)
+			}
+			if string(data) != "history" {
+				t.Errorf("expected %q, got %q", "history", string(data))
+			}
+		})
+	}
+
+	if _, err := MountArchive(filepath.Join(t.TempDir(), "missing.zip")); err == nil {
+		t.Errorf("expected error for missing archive")
+	}
+	unsupported := filepath.Join(t.TempDir(), "home.rar")
+	os.WriteFile(unsupported, []byte{}, 0644)
+	if _, err := MountArchive(unsupported); err == nil {
+		t.Errorf("expected error for unsupported archive")
+	}
+}
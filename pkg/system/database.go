@@ -0,0 +1,180 @@
+package system
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"modernc.org/sqlite"
+)
+
+// suffixes of the files SQLite keeps next to a database
+var sqliteSidecars = []string{"-wal", "-shm", "-journal"}
+
+// snapshotAttempts is the number of copies of a database tried while the browser modifies it
+const snapshotAttempts = 3
+
+var (
+	tempDirMu sync.Mutex
+	// tempDir contains the snapshots of the databases, removed by Cleanup
+	tempDir string
+)
+
+// OpenDatabase opens a snapshot of a SQLite database, consistent even while the browser writes to it.
+// The database and the files SQLite keeps next to it (write-ahead log, shared memory, rollback journal)
+// are copied from FileSystem to a private temporary directory, removed when the database is closed,
+// so that the pages of the write-ahead log not yet checkpointed are read.
+func OpenDatabase(path string) (*sql.DB, error) {
+	local, err := snapshot(path)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(&snapshotConnector{
+		dsn: fmt.Sprintf("file:%s?_pragma=query_only(1)", local),
+		dir: filepath.Dir(local),
+	}), nil
+}
+
+// snapshotConnector opens the connections to a snapshot, and removes the snapshot when the database is closed
+type snapshotConnector struct {
+	dsn string
+	dir string
+}
+
+var _ io.Closer = &snapshotConnector{}
+
+func (o *snapshotConnector) Connect(context.Context) (driver.Conn, error) {
+	return o.Driver().Open(o.dsn)
+}
+
+func (o *snapshotConnector) Driver() driver.Driver {
+	return &sqlite.Driver{}
+}
+
+func (o *snapshotConnector) Close() error {
+	return os.RemoveAll(o.dir)
+}
+
+// snapshot copies a file of FileSystem and the files SQLite keeps next to it to a new private directory,
+// and returns the path of the copy. The files are copied again when they are modified during the copy.
+func snapshot(path string) (string, error) {
+	if _, err := FileSystem.Stat(path); err != nil {
+		return "", err
+	}
+	base, err := snapshotsDir()
+	if err != nil {
+		return "", err
+	}
+	dir, err := os.MkdirTemp(base, "")
+	if err != nil {
+		return "", err
+	}
+	local := filepath.Join(dir, filepath.Base(path))
+	for attempt := 1; ; attempt++ {
+		before := fileStates(path)
+		if err = copyDatabase(path, local); err != nil {
+			os.RemoveAll(dir)
+			return "", err
+		}
+		if slices.Equal(before, fileStates(path)) {
+			break
+		}
+		if attempt == snapshotAttempts {
+			log.Debug("database modified during its snapshot", "path", path)
+			break
+		}
+	}
+	return local, nil
+}
+
+// copyDatabase copies a database and the files SQLite keeps next to it, removing the files of a previous copy
+func copyDatabase(path string, local string) error {
+	if err := copyFile(path, local); err != nil {
+		return err
+	}
+	for _, sidecar := range sqliteSidecars {
+		if err := os.Remove(local + sidecar); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if _, err := FileSystem.Stat(path + sidecar); err != nil {
+			continue
+		}
+		if err := copyFile(path+sidecar, local+sidecar); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// fileStates returns the sizes and modification times of a database and of the files SQLite keeps next to it
+func fileStates(path string) []fileState {
+	var result []fileState
+	for _, suffix := range append([]string{""}, sqliteSidecars...) {
+		var state fileState
+		if info, err := FileSystem.Stat(path + suffix); err == nil {
+			state = fileState{size: info.Size(), modTime: info.ModTime()}
+		}
+		result = append(result, state)
+	}
+	return result
+}
+
+func copyFile(path string, local string) error {
+	src, err := FileSystem.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	// files of tar archives share their read offset between opens
+	if _, err = src.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	dst, err := os.Create(local)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// snapshotsDir returns the temporary directory containing the snapshots, created once
+func snapshotsDir() (string, error) {
+	tempDirMu.Lock()
+	defer tempDirMu.Unlock()
+	if tempDir == "" {
+		dir, err := os.MkdirTemp("", "browsers-mcp-server-")
+		if err != nil {
+			return "", err
+		}
+		tempDir = dir
+	}
+	return tempDir, nil
+}
+
+// Cleanup removes the snapshots of the databases not closed
+func Cleanup() error {
+	tempDirMu.Lock()
+	defer tempDirMu.Unlock()
+	if tempDir == "" {
+		return nil
+	}
+	dir := tempDir
+	tempDir = ""
+	return os.RemoveAll(dir)
+}
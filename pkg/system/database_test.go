@@ -0,0 +1,148 @@
+package system
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+// writeWALDatabase creates a database in WAL mode whose last rows are in the write-ahead log only,
+// and returns its path and the connection keeping the write-ahead log from being checkpointed
+func writeWALDatabase(t *testing.T) (string, *sql.DB) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "places.sqlite")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	for _, statement := range []string{
+		`PRAGMA journal_mode=WAL`,
+		`PRAGMA wal_autocheckpoint=0`,
+		`CREATE TABLE moz_places (url TEXT)`,
+		`INSERT INTO moz_places (url) VALUES ('https://www.mozilla.org/'), ('https://developer.mozilla.org/')`,
+	} {
+		if _, err = db.Exec(statement); err != nil {
+			t.Fatalf("failed to execute %q: %v", statement, err)
+		}
+	}
+	if _, err = os.Stat(path + "-wal"); err != nil {
+		t.Fatalf("expected the write-ahead log to exist, got %v", err)
+	}
+	return path, db
+}
+
+func countPlaces(t *testing.T, path string) int {
+	t.Helper()
+	db, err := OpenDatabase(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer db.Close()
+	var count int
+	if err = db.QueryRow(`SELECT COUNT(*) FROM moz_places`).Scan(&count); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return count
+}
+
+func TestOpenDatabase(t *testing.T) {
+	defer func() { FileSystem = afero.NewOsFs() }()
+	defer Cleanup()
+
+	path, writer := writeWALDatabase(t)
+	defer writer.Close()
+
+	t.Run("database written by a running browser", func(t *testing.T) {
+		FileSystem = afero.NewOsFs()
+		if count := countPlaces(t, path); count != 2 {
+			t.Errorf("expected the rows of the write-ahead log to be read, got %d rows", count)
+		}
+		if _, err := writer.Exec(`INSERT INTO moz_places (url) VALUES ('https://addons.mozilla.org/')`); err != nil {
+			t.Fatalf("failed to insert: %v", err)
+		}
+		if count := countPlaces(t, path); count != 3 {
+			t.Errorf("expected a new snapshot to read the last rows, got %d rows", count)
+		}
+	})
+
+	t.Run("database of FileSystem", func(t *testing.T) {
+		FileSystem = afero.NewMemMapFs()
+		for _, suffix := range []string{"", "-wal", "-shm"} {
+			data, err := os.ReadFile(path + suffix)
+			if err != nil {
+				t.Fatal(err)
+			}
+			WriteFile("/profile/places.sqlite"+suffix, data, 0644)
+		}
+		if count := countPlaces(t, "/profile/places.sqlite"); count != 3 {
+			t.Errorf("expected 3 rows, got %d", count)
+		}
+	})
+
+	t.Run("missing database", func(t *testing.T) {
+		FileSystem = afero.NewMemMapFs()
+		if _, err := OpenDatabase("/profile/places.sqlite"); !os.IsNotExist(err) {
+			t.Errorf("expected a not exist error, got %v", err)
+		}
+	})
+
+	t.Run("read only snapshot", func(t *testing.T) {
+		FileSystem = afero.NewOsFs()
+		db, err := OpenDatabase(path)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		defer db.Close()
+		if _, err = db.Exec(`DELETE FROM moz_places`); err == nil {
+			t.Error("expected the snapshot to be read only")
+		}
+	})
+}
+
+func TestSnapshotCleanup(t *testing.T) {
+	FileSystem = afero.NewMemMapFs()
+	defer func() { FileSystem = afero.NewOsFs() }()
+
+	WriteFile("/profile/places.sqlite", []byte("db"), 0644)
+	WriteFile("/profile/places.sqlite-wal", []byte("wal"), 0644)
+
+	local, err := snapshot("/profile/places.sqlite")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if filepath.Base(local) != "places.sqlite" {
+		t.Errorf("expected the snapshot to keep the name of the database, got %s", local)
+	}
+	for path, expected := range map[string]string{local: "db", local + "-wal": "wal"} {
+		data, err := os.ReadFile(path)
+		if err != nil || string(data) != expected {
+			t.Errorf("expected %s to contain %q, got %q, %v", path, expected, string(data), err)
+		}
+	}
+	if again, _ := snapshot("/profile/places.sqlite"); again == local {
+		t.Errorf("expected a new snapshot, got %s twice", local)
+	}
+
+	db, err := OpenDatabase("/profile/places.sqlite")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err = db.Close(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	entries, _ := os.ReadDir(tempDir)
+	if len(entries) != 2 {
+		t.Errorf("expected the snapshot of the closed database to be removed, got %d snapshots", len(entries))
+	}
+
+	if err = Cleanup(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err = os.Stat(local); !os.IsNotExist(err) {
+		t.Errorf("expected snapshot to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Home overrides the home directory of the user whose browsers are read
+var Home string
+
+// DataDirectories overrides the directories where the browsers store their data, indexed by browser name.
+// The directories can contain environment variables and start with `~`.
+var DataDirectories map[string][]string
+
+// HomeDir returns the home directory of the user whose browsers are read
+func HomeDir() string {
+	if Home != "" {
+		return Home
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return home
+	}
+	return os.Getenv("HOME")
+}
+
+// HomePath returns a path relative to the home directory
+func HomePath(elem ...string) string {
+	return Native().HomePath(elem...)
+}
+
+// AppDataPath returns a path relative to the roaming application data directory on Windows (%APPDATA%)
+func AppDataPath(elem ...string) string {
+	return Native().AppDataPath(elem...)
+}
+
+// LocalAppDataPath returns a path relative to the local application data directory on Windows (%LOCALAPPDATA%)
+func LocalAppDataPath(elem ...string) string {
+	return Native().LocalAppDataPath(elem...)
+}
+
+// ExpandPath expands the environment variables of a path, and a leading `~` to the home directory
+func ExpandPath(path string) string {
+	path = os.ExpandEnv(path)
+	if path == "~" {
+		return HomeDir()
+	}
+	if strings.HasPrefix(path, "~/") || strings.HasPrefix(path, `~\`) {
+		return filepath.Join(HomeDir(), path[2:])
+	}
+	return path
+}
+
+// DataDirectory is a directory configured to replace the default locations of the data of a browser
+type DataDirectory struct {
+	// Name is empty when a single directory is configured for the browser, the path of the directory otherwise
+	Name string
+	Path string
+}
+
+// ConfiguredDataDirectories returns the directories configured for a browser, or nil to use the default locations
+func ConfiguredDataDirectories(browser string) []DataDirectory {
+	paths := DataDirectories[browser]
+	var result []DataDirectory
+	for _, path := range paths {
+		dir := DataDirectory{Path: ExpandPath(path)}
+		if len(paths) > 1 {
+			dir.Name = dir.Path
+		}
+		result = append(result, dir)
+	}
+	return result
+}
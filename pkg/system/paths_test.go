@@ -0,0 +1,64 @@
+package system
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPaths(t *testing.T) {
+	t.Setenv("APPDATA", filepath.Join("/windows", "Roaming"))
+	t.Setenv("LOCALAPPDATA", filepath.Join("/windows", "Local"))
+	t.Setenv("BROWSERS", "/data/browsers")
+	Home = ""
+	defer func() { Home = "" }()
+
+	if expected := filepath.Join("/windows", "Roaming", "Mozilla"); AppDataPath("Mozilla") != expected {
+		t.Errorf("expected %s, got %s", expected, AppDataPath("Mozilla"))
+	}
+	if expected := filepath.Join("/windows", "Local", "Google"); LocalAppDataPath("Google") != expected {
+		t.Errorf("expected %s, got %s", expected, LocalAppDataPath("Google"))
+	}
+
+	Home = "/home/other"
+	for _, tt := range []struct {
+		actual   string
+		expected string
+	}{
+		{HomePath(".config", "chromium"), filepath.Join("/home/other", ".config", "chromium")},
+		{AppDataPath("Mozilla"), filepath.Join("/home/other", "AppData", "Roaming", "Mozilla")},
+		{LocalAppDataPath("Google"), filepath.Join("/home/other", "AppData", "Local", "Google")},
+		{ExpandPath("~/portable/chrome"), filepath.Join("/home/other", "portable", "chrome")},
+		{ExpandPath("$BROWSERS/chrome"), "/data/browsers/chrome"},
+		{ExpandPath("/absolute/~/path"), "/absolute/~/path"},
+	} {
+		if tt.actual != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, tt.actual)
+		}
+	}
+}
+
+func TestConfiguredDataDirectories(t *testing.T) {
+	t.Setenv("BROWSERS", "/data/browsers")
+	DataDirectories = map[string][]string{
+		"chrome":  {"$BROWSERS/chrome"},
+		"firefox": {"$BROWSERS/firefox", "/portable/firefox"},
+	}
+	defer func() { DataDirectories = nil }()
+
+	for browser, expected := range map[string][]DataDirectory{
+		"chrome": {
+			{Path: "/data/browsers/chrome"},
+		},
+		"firefox": {
+			{Name: "/data/browsers/firefox", Path: "/data/browsers/firefox"},
+			{Name: "/portable/firefox", Path: "/portable/firefox"},
+		},
+		"brave": nil,
+	} {
+		if diff := cmp.Diff(expected, ConfiguredDataDirectories(browser)); diff != "" {
+			t.Errorf("%s: data directories mismatch (-want +got):\n%s", browser, diff)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// Platform is a system where browsers store their data
+type Platform struct {
+	// Os is the layout of the data: darwin, linux or windows
+	Os string
+	// Home is the home directory, the one of the current user if empty
+	Home string
+}
+
+// Native returns the platform the server runs on, or the platform the archive comes from
+func Native() Platform {
+	return Platform{Os: Os, Home: Home}
+}
+
+// HomeDir returns the home directory of the platform
+func (o Platform) HomeDir() string {
+	if o.Home != "" {
+		return o.Home
+	}
+	return HomeDir()
+}
+
+// HomePath returns a path relative to the home directory of the platform
+func (o Platform) HomePath(elem ...string) string {
+	return filepath.Join(append([]string{o.HomeDir()}, elem...)...)
+}
+
+// AppDataPath returns a path relative to the roaming application data directory on Windows (%APPDATA%)
+func (o Platform) AppDataPath(elem ...string) string {
+	appData := os.Getenv("APPDATA")
+	if o.Home != "" || appData == "" {
+		appData = filepath.Join(o.HomeDir(), "AppData", "Roaming")
+	}
+	return filepath.Join(append([]string{appData}, elem...)...)
+}
+
+// LocalAppDataPath returns a path relative to the local application data directory on Windows (%LOCALAPPDATA%)
+func (o Platform) LocalAppDataPath(elem ...string) string {
+	localAppData := os.Getenv("LOCALAPPDATA")
+	if o.Home != "" || localAppData == "" {
+		localAppData = filepath.Join(o.HomeDir(), "AppData", "Local")
+	}
+	return filepath.Join(append([]string{localAppData}, elem...)...)
+}
+
+// WSLUsersDirectory is the directory containing the home directories of the Windows users, as mounted in WSL
+var WSLUsersDirectory = "/mnt/c/Users"
+
+// directories of WSLUsersDirectory which are not home directories of users
+var wslSystemUsers = []string{"All Users", "Default", "Default User", "Public"}
+
+// ProcVersionFile is the file describing the kernel the server runs on, read from the local disk
+// even when the browsers data are read from an archive
+var ProcVersionFile = "/proc/version"
+
+// IsWSL indicates if the server runs in a Linux distribution of the Windows Subsystem for Linux
+func IsWSL() bool {
+	if Os != "linux" {
+		return false
+	}
+	if os.Getenv("WSL_DISTRO_NAME") != "" {
+		return true
+	}
+	version, err := os.ReadFile(ProcVersionFile)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(string(version)), "microsoft")
+}
+
+// WSL returns the Windows platform hosting the WSL distribution the server runs in.
+// The Windows user is the one having the same name as the Linux user, or the single user of the Windows system.
+func WSL() (Platform, bool) {
+	if !IsWSL() {
+		return Platform{}, false
+	}
+	entries, err := afero.ReadDir(FileSystem, WSLUsersDirectory)
+	if err != nil {
+		return Platform{}, false
+	}
+	var users []string
+	for _, entry := range entries {
+		if entry.IsDir() && !slices.Contains(wslSystemUsers, entry.Name()) {
+			users = append(users, entry.Name())
+		}
+	}
+	user := os.Getenv("USER")
+	for _, candidate := range users {
+		if strings.EqualFold(candidate, user) {
+			return Platform{Os: "windows", Home: filepath.Join(WSLUsersDirectory, candidate)}, true
+		}
+	}
+	if len(users) == 1 {
+		return Platform{Os: "windows", Home: filepath.Join(WSLUsersDirectory, users[0])}, true
+	}
+	return Platform{}, false
+}
@@ -0,0 +1,118 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestWSL(t *testing.T) {
+	defer func() {
+		Os = "linux"
+		FileSystem = afero.NewOsFs()
+	}()
+	for _, tt := range []struct {
+		name     string
+		os       string
+		distro   string
+		version  string
+		user     string
+		users    []string
+		wantOk   bool
+		wantHome string
+	}{
+		{
+			name:   "not linux",
+			os:     "windows",
+			distro: "Ubuntu",
+			users:  []string{"jane"},
+		},
+		{
+			name:  "not WSL",
+			os:    "linux",
+			users: []string{"jane"},
+		},
+		{
+			name:     "WSL detected from the distribution name",
+			os:       "linux",
+			distro:   "Ubuntu",
+			users:    []string{"jane"},
+			wantOk:   true,
+			wantHome: "/mnt/c/Users/jane",
+		},
+		{
+			name:     "WSL detected from the kernel version",
+			os:       "linux",
+			version:  "Linux version 5.15.167.4-microsoft-standard-WSL2",
+			users:    []string{"jane"},
+			wantOk:   true,
+			wantHome: "/mnt/c/Users/jane",
+		},
+		{
+			name:     "system users are ignored",
+			os:       "linux",
+			distro:   "Ubuntu",
+			users:    []string{"All Users", "Default", "Default User", "Public", "jane"},
+			wantOk:   true,
+			wantHome: "/mnt/c/Users/jane",
+		},
+		{
+			name:     "user with the same name is preferred",
+			os:       "linux",
+			distro:   "Ubuntu",
+			user:     "joe",
+			users:    []string{"jane", "Joe"},
+			wantOk:   true,
+			wantHome: "/mnt/c/Users/Joe",
+		},
+		{
+			name:   "several users",
+			os:     "linux",
+			distro: "Ubuntu",
+			user:   "jim",
+			users:  []string{"jane", "joe"},
+		},
+		{
+			name:   "no users",
+			os:     "linux",
+			distro: "Ubuntu",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			FileSystem = afero.NewMemMapFs()
+			Os = tt.os
+			t.Setenv("WSL_DISTRO_NAME", tt.distro)
+			t.Setenv("USER", tt.user)
+			procVersionFile := ProcVersionFile
+			ProcVersionFile = filepath.Join(t.TempDir(), "version")
+			t.Cleanup(func() { ProcVersionFile = procVersionFile })
+			if tt.version != "" {
+				os.WriteFile(ProcVersionFile, []byte(tt.version), 0644)
+			}
+			for _, user := range tt.users {
+				FileSystem.MkdirAll(WSLUsersDirectory+"/"+user, 0755)
+			}
+			platform, ok := WSL()
+			if ok != tt.wantOk {
+				t.Fatalf("expected %v, got %v", tt.wantOk, ok)
+			}
+			if ok && (platform.Os != "windows" || platform.Home != tt.wantHome) {
+				t.Errorf("expected windows platform in %s, got %v", tt.wantHome, platform)
+			}
+		})
+	}
+}
+
+func TestPlatformPaths(t *testing.T) {
+	t.Setenv("APPDATA", "/elsewhere/Roaming")
+	t.Setenv("LOCALAPPDATA", "/elsewhere/Local")
+	platform := Platform{Os: "windows", Home: "/mnt/c/Users/jane"}
+	if got := platform.AppDataPath("Mozilla"); got != "/mnt/c/Users/jane/AppData/Roaming/Mozilla" {
+		t.Errorf("unexpected roaming path %s", got)
+	}
+	if got := platform.LocalAppDataPath("Google"); got != "/mnt/c/Users/jane/AppData/Local/Google" {
+		t.Errorf("unexpected local path %s", got)
+	}
+}
@@ -0,0 +1,90 @@
+package system
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/log"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Schema is the registry of the versions of the schema of a database supported by a reader,
+// with the variant of the queries the reader runs on each range of versions.
+type Schema[Q any] struct {
+	// Database names the database in the errors, for example "chrome History"
+	Database string
+	// VersionQuery returns the version of the schema, as an integer
+	VersionQuery string
+	// Ranges are the supported ranges of versions
+	Ranges []SchemaRange[Q]
+	// MaxTestedVersion is the most recent version the queries have been tested on.
+	// The more recent versions are read with the queries of their range, after a warning.
+	MaxTestedVersion int
+}
+
+// untestedSchemas are the databases and versions for which a warning has been logged
+var untestedSchemas sync.Map
+
+// SchemaRange is a range of versions of a schema, sharing the same variant of the queries
+type SchemaRange[Q any] struct {
+	MinVersion int
+	// MaxVersion is 0 for a range including the versions to come
+	MaxVersion int
+	Queries    Q
+}
+
+// SchemaDatabase is a snapshot of a database, with the queries to run on the version of its schema
+type SchemaDatabase[Q any] struct {
+	*sql.DB
+	Version  int
+	Queries  Q
+	database string
+}
+
+// Open opens a snapshot of the database at path, and selects the queries supporting the version of its schema.
+// An error is returned when the version is not supported.
+func (o Schema[Q]) Open(path string) (*SchemaDatabase[Q], error) {
+	db, err := OpenDatabase(path)
+	if err != nil {
+		return nil, err
+	}
+	var version int
+	if err = db.QueryRow(o.VersionQuery).Scan(&version); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("unable to read the schema version of %s: %w", o.Database, err)
+	}
+	for _, r := range o.Ranges {
+		if version >= r.MinVersion && (r.MaxVersion == 0 || version <= r.MaxVersion) {
+			if o.MaxTestedVersion > 0 && version > o.MaxTestedVersion {
+				if _, warned := untestedSchemas.LoadOrStore(fmt.Sprintf("%s:%d", o.Database, version), true); !warned {
+					log.Warn("schema version more recent than the tested ones, results may be incomplete", "database", o.Database, "version", version, "maxTestedVersion", o.MaxTestedVersion)
+				}
+			}
+			return &SchemaDatabase[Q]{
+				DB:       db,
+				Version:  version,
+				Queries:  r.Queries,
+				database: o.Database,
+			}, nil
+		}
+	}
+	db.Close()
+	return nil, fmt.Errorf("unsupported schema version %d for %s", version, o.Database)
+}
+
+// Err returns the error of a query, reporting the version of the schema as unsupported
+// when the query does not match the schema (missing table or column)
+func (o *SchemaDatabase[Q]) Err(err error) error {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) || sqliteErr.Code()&0xff != sqlite3.SQLITE_ERROR {
+		return err
+	}
+	if message := sqliteErr.Error(); strings.Contains(message, "no such table") || strings.Contains(message, "no such column") {
+		return fmt.Errorf("unsupported schema version %d for %s: %w", o.Version, o.database, err)
+	}
+	return err
+}
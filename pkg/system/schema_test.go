@@ -0,0 +1,148 @@
+package system
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/afero"
+)
+
+// writeVersionedDatabase creates a database whose schema version is declared in its user_version pragma
+func writeVersionedDatabase(t *testing.T, statements ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "places.sqlite")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer db.Close()
+	for _, statement := range statements {
+		if _, err = db.Exec(statement); err != nil {
+			t.Fatalf("failed to execute %q: %v", statement, err)
+		}
+	}
+	return path
+}
+
+var testSchema = Schema[string]{
+	Database:     "test places.sqlite",
+	VersionQuery: `PRAGMA user_version`,
+	Ranges: []SchemaRange[string]{
+		{MinVersion: 10, MaxVersion: 19, Queries: `SELECT url FROM moz_places`},
+		{MinVersion: 20, Queries: `SELECT url FROM places`},
+	},
+	MaxTestedVersion: 30,
+}
+
+func TestSchemaOpen(t *testing.T) {
+	FileSystem = afero.NewOsFs()
+	defer Cleanup()
+
+	for _, tt := range []struct {
+		name            string
+		statements      []string
+		expectedQueries string
+		expectedError   string
+	}{
+		{
+			name:            "first range",
+			statements:      []string{`PRAGMA user_version = 15`},
+			expectedQueries: `SELECT url FROM moz_places`,
+		},
+		{
+			name:            "open-ended range",
+			statements:      []string{`PRAGMA user_version = 25`},
+			expectedQueries: `SELECT url FROM places`,
+		},
+		{
+			name:          "version too old",
+			statements:    []string{`PRAGMA user_version = 9`},
+			expectedError: "unsupported schema version 9 for test places.sqlite",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := testSchema.Open(writeVersionedDatabase(t, tt.statements...))
+			if tt.expectedError != "" {
+				if err == nil || err.Error() != tt.expectedError {
+					t.Fatalf("expected error %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			defer db.Close()
+			if db.Queries != tt.expectedQueries {
+				t.Errorf("expected queries %q, got %q", tt.expectedQueries, db.Queries)
+			}
+		})
+	}
+
+	t.Run("missing version table", func(t *testing.T) {
+		schema := Schema[string]{Database: "test History", VersionQuery: `SELECT value FROM meta WHERE key = 'version'`}
+		_, err := schema.Open(writeVersionedDatabase(t, `CREATE TABLE urls (url TEXT)`))
+		if err == nil || !strings.HasPrefix(err.Error(), "unable to read the schema version of test History: ") {
+			t.Errorf("expected unreadable version error, got %v", err)
+		}
+	})
+
+	t.Run("version more recent than the tested ones", func(t *testing.T) {
+		var logs bytes.Buffer
+		log.SetOutput(&logs)
+		defer log.SetOutput(os.Stderr)
+		for range 2 {
+			db, err := testSchema.Open(writeVersionedDatabase(t, `PRAGMA user_version = 42`))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			db.Close()
+			if db.Queries != `SELECT url FROM places` {
+				t.Errorf("expected the queries of the open-ended range, got %q", db.Queries)
+			}
+		}
+		if count := strings.Count(logs.String(), "more recent than the tested ones"); count != 1 {
+			t.Errorf("expected a single warning, got %d:\n%s", count, logs.String())
+		}
+	})
+
+	t.Run("query not matching the schema", func(t *testing.T) {
+		db, err := testSchema.Open(writeVersionedDatabase(t, `PRAGMA user_version = 15`, `CREATE TABLE moz_places (uri TEXT)`))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		defer db.Close()
+		_, err = db.Query(db.Queries)
+		err = db.Err(err)
+		if err == nil || !strings.HasPrefix(err.Error(), "unsupported schema version 15 for test places.sqlite: ") {
+			t.Errorf("expected unsupported schema version error, got %v", err)
+		}
+	})
+}
+
+func TestSchemaDatabaseErr(t *testing.T) {
+	FileSystem = afero.NewOsFs()
+	defer Cleanup()
+
+	db, err := testSchema.Open(writeVersionedDatabase(t, `PRAGMA user_version = 15`, `CREATE TABLE moz_places (url TEXT)`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer db.Close()
+
+	// errors not related to the schema are returned as is
+	_, err = db.Query(`SELECT url FROM moz_places WHERE`)
+	if wrapped := db.Err(err); wrapped == nil || wrapped != err {
+		t.Errorf("expected the syntax error to be returned as is, got %v", wrapped)
+	}
+
+	_, err = db.Query(`SELECT url FROM moz_historyvisits`)
+	err = db.Err(err)
+	if err == nil || !strings.HasPrefix(err.Error(), "unsupported schema version 15 for test places.sqlite: ") {
+		t.Errorf("expected unsupported schema version error, got %v", err)
+	}
+}
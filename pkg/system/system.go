@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"github.com/spf13/afero"
 	"k8s.io/klog/v2"
@@ -45,3 +46,19 @@ func WriteFile(name string, data []byte, perm os.FileMode) error {
 	_, err = f.Write(data)
 	return err
 }
+
+// LastModified returns the most recent modification time of the existing files among paths,
+// or the zero time if none of them exists.
+func LastModified(paths ...string) time.Time {
+	var result time.Time
+	for _, path := range paths {
+		info, err := FileSystem.Stat(path)
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(result) {
+			result = info.ModTime()
+		}
+	}
+	return result
+}
@@ -0,0 +1,54 @@
+package test
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+	_ "modernc.org/sqlite"
+)
+
+// WriteDatabase creates a SQLite database at path in system.FileSystem (a memory filesystem in the tests),
+// by executing the statements on a temporary database
+func WriteDatabase(t *testing.T, path string, statements ...string) {
+	t.Helper()
+	local := filepath.Join(t.TempDir(), filepath.Base(path))
+	db, err := sql.Open("sqlite", local)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	for _, statement := range statements {
+		if _, err = db.Exec(statement); err != nil {
+			db.Close()
+			t.Fatalf("failed to execute %q: %v", statement, err)
+		}
+	}
+	if err = db.Close(); err != nil {
+		t.Fatalf("failed to close database: %v", err)
+	}
+	data, err := os.ReadFile(local)
+	if err != nil {
+		t.Fatalf("failed to read database: %v", err)
+	}
+	if err = system.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("failed to write database: %v", err)
+	}
+}
+
+// Fixture is a SQLite database written by the tests at a path of system.FileSystem, with its schema
+type Fixture struct {
+	Path string
+	// Schema are the statements creating the tables of the database and setting its version
+	Schema []string
+}
+
+// Write creates the database with its schema, then executes the statements inserting its rows.
+// It returns the directory containing the database.
+func (o Fixture) Write(t *testing.T, rows ...string) string {
+	t.Helper()
+	WriteDatabase(t, o.Path, slices.Concat(o.Schema, rows)...)
+	return filepath.Dir(o.Path)
+}
@@ -0,0 +1,18 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/feloy/browsers-mcp-server/pkg/system"
+)
+
+// MemFileSystem replaces system.FileSystem with a memory filesystem, restored at the end of the test
+func MemFileSystem(t *testing.T) afero.Fs {
+	t.Helper()
+	previous := system.FileSystem
+	system.FileSystem = afero.NewMemMapFs()
+	t.Cleanup(func() { system.FileSystem = previous })
+	return system.FileSystem
+}